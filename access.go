@@ -0,0 +1,44 @@
+package types
+
+import (
+	"io/fs"
+	"strings"
+)
+
+// Access is a set of permissions which can be checked using [Path.CheckAccess] and [Path.CheckOwnerAccess].
+//
+// Values may be combined, e.g. AccessRead | AccessWrite.
+type Access uint32
+
+const (
+	// AccessExecute checks whether a file may be executed or a directory may be searched.
+	AccessExecute Access = 1 << iota
+
+	// AccessWrite checks whether a file or directory may be written to.
+	AccessWrite
+
+	// AccessRead checks whether a file or directory may be read.
+	AccessRead
+)
+
+// String returns the [Access] object as a string, such as "rw-".
+func (a Access) String() string {
+	var sb strings.Builder
+	for _, c := range []struct {
+		flag Access
+		ch   byte
+	}{{AccessRead, 'r'}, {AccessWrite, 'w'}, {AccessExecute, 'x'}} {
+		if a&c.flag != 0 {
+			sb.WriteByte(c.ch)
+		} else {
+			sb.WriteByte('-')
+		}
+	}
+	return sb.String()
+}
+
+// permits returns whether or not the given permission bits for a single class (owner, group or other) allow the
+// requested access.
+func (a Access) permits(bits fs.FileMode) bool {
+	return Access(bits&07)&a == a
+}
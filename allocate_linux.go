@@ -0,0 +1,16 @@
+//go:build linux
+
+package types
+
+import (
+	"os"
+	"syscall"
+)
+
+// allocateFile reserves disk space for the file up to the given size using fallocate(2).
+func allocateFile(file *os.File, size int64) error {
+	if size == 0 {
+		return nil
+	}
+	return syscall.Fallocate(int(file.Fd()), 0, 0, size)
+}
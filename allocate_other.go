@@ -0,0 +1,13 @@
+//go:build !linux
+
+package types
+
+import (
+	"errors"
+	"os"
+)
+
+// allocateFile is not supported on this platform.
+func allocateFile(file *os.File, size int64) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ByteRate is a rate of data transfer stored as a number of bytes per second.
+//
+// It is parsed from a [Size] followed by a "/" and either a unit of time or a [Duration], e.g. "10MB/s", "1GiB/h",
+// "100Mbit/s" or "500MB/5m". A value without a "/" is treated as a number of bytes per second.
+type ByteRate float64
+
+// NewByteRate creates a new [ByteRate] object for the given amount of data transferred over the given duration.
+//
+// If the duration is 0 or less, 0 is returned.
+func NewByteRate(size Size, per Duration) ByteRate {
+	if per <= 0 {
+		return 0
+	}
+	return ByteRate(float64(size) / time.Duration(per).Seconds())
+}
+
+// ParseByteRate parses the given string into a [ByteRate] object.
+//
+// If an empty string is supplied, 0 is returned.
+func ParseByteRate(rate string) (ByteRate, error) {
+	sizeStr, perStr, found := strings.Cut(strings.TrimSpace(rate), "/")
+	if !found {
+		size, err := ParseSize(sizeStr)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse rate '%s': %w", rate, err)
+		}
+		return ByteRate(size), nil
+	}
+
+	size, err := ParseSize(sizeStr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse rate '%s': %w", rate, err)
+	}
+	perStr = strings.TrimSpace(perStr)
+	if perStr != "" && (perStr[0] < '0' || perStr[0] > '9') {
+		perStr = "1" + perStr
+	}
+	per, err := ParseDuration(perStr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse rate '%s': %w", rate, err)
+	}
+	if per <= 0 {
+		return 0, fmt.Errorf("failed to parse rate '%s': duration must be greater than 0", rate)
+	}
+	return NewByteRate(size, per), nil
+}
+
+// BytesPerSecond returns the rate as a number of bytes per second.
+func (r ByteRate) BytesPerSecond() float64 {
+	return float64(r)
+}
+
+// Duration returns how long it takes to transfer the given amount of data at this rate.
+//
+// If the rate is 0 or less, 0 is returned.
+func (r ByteRate) Duration(size Size) Duration {
+	if r <= 0 {
+		return 0
+	}
+	return Duration(float64(size) / float64(r) * float64(time.Second))
+}
+
+// MarshalJSON marshals the [ByteRate] object to JSON.
+func (r ByteRate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// MarshalText marshals the [ByteRate] object to plain text.
+func (r ByteRate) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Per returns the amount of data transferred at this rate over the given duration.
+func (r ByteRate) Per(d Duration) Size {
+	return Size(float64(r) * time.Duration(d).Seconds())
+}
+
+// String returns the [ByteRate] object as a string, such as "10MB/s".
+func (r ByteRate) String() string {
+	return Size(r).String() + "/s"
+}
+
+// UnmarshalJSON parses the JSON data into a [ByteRate] object.
+//
+// A number is treated as a number of bytes per second. If an empty string is supplied, 0 is stored.
+func (r *ByteRate) UnmarshalJSON(data []byte) error {
+	var fval64 float64
+	if err := json.Unmarshal(data, &fval64); err == nil {
+		*r = ByteRate(fval64)
+		return nil
+	}
+
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	rate, err := ParseByteRate(sval)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// UnmarshalText parses the text into a [ByteRate] object.
+//
+// If an empty string is supplied, 0 is stored.
+func (r *ByteRate) UnmarshalText(data []byte) error {
+	rate, err := ParseByteRate(string(data))
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
@@ -0,0 +1,38 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+// TODO: implement additional testing and benchmarks
+
+func TestByteRate1(t *testing.T) {
+	rates := map[string]float64{
+		"10MB/s":    10000000,
+		"1GiB/h":    1073741824.0 / 3600,
+		"100Mbit/s": 12500000,
+		"500MB/5m":  500000000.0 / 300,
+		"2048":      2048,
+	}
+	for str, expected := range rates {
+		rate, err := types.ParseByteRate(str)
+		if err != nil {
+			t.Errorf("failed to parse rate: %v", err)
+		} else if rate.BytesPerSecond() != expected {
+			t.Errorf("expected '%s' to be %g bytes/s but got %g", str, expected, rate.BytesPerSecond())
+		} else {
+			t.Logf("rate: %s", rate)
+		}
+	}
+
+	rate := types.NewByteRate(types.Megabyte, types.Duration(time.Second))
+	if d := rate.Duration(types.Gigabyte); time.Duration(d) != 1000*time.Second {
+		t.Errorf("unexpected transfer time: %s", d)
+	}
+	if _, err := types.ParseByteRate("10MB/0s"); err == nil {
+		t.Errorf("expected zero duration to fail")
+	}
+}
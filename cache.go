@@ -0,0 +1,240 @@
+package types
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// CacheEvictReason describes why an entry was removed from a [Cache] object.
+type CacheEvictReason int
+
+const (
+	// CacheEvictCapacity indicates the entry was removed to stay within the maximum entries or memory of the cache.
+	CacheEvictCapacity CacheEvictReason = iota
+
+	// CacheEvictExpired indicates the entry was removed because its time to live elapsed.
+	CacheEvictExpired
+
+	// CacheEvictDeleted indicates the entry was removed by a call to [Cache.Delete] or [Cache.Clear].
+	CacheEvictDeleted
+)
+
+// String returns the [CacheEvictReason] object as a string.
+func (r CacheEvictReason) String() string {
+	switch r {
+	case CacheEvictCapacity:
+		return "capacity"
+	case CacheEvictExpired:
+		return "expired"
+	case CacheEvictDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+// CacheOptions holds the options for creating a [Cache] object.
+//
+// Limits which are left at their zero value are not enforced.
+type CacheOptions[K comparable, V any] struct {
+	// MaxEntries is the maximum number of entries to keep in the cache.
+	MaxEntries int `json:"max_entries" yaml:"max_entries" mapstructure:"max_entries"`
+
+	// MaxMemory is the maximum total size of the entries in the cache as reported by SizeFunc.
+	MaxMemory Size `json:"max_memory" yaml:"max_memory" mapstructure:"max_memory"`
+
+	// TTL is the default amount of time after which an entry expires.
+	TTL Duration `json:"ttl" yaml:"ttl" mapstructure:"ttl"`
+
+	// Clock provides the current time when checking for expired entries. If nil, [SystemClock] is used.
+	Clock Clock `json:"-" yaml:"-" mapstructure:"-"`
+
+	// OnEvict is called after an entry has been removed from the cache.
+	OnEvict func(key K, value V, reason CacheEvictReason) `json:"-" yaml:"-" mapstructure:"-"`
+
+	// SizeFunc returns the size of an entry. It must be set for MaxMemory to be enforced.
+	SizeFunc func(key K, value V) Size `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+// Cache is a generic least recently used cache with optional limits on entries, memory and age.
+//
+// Cache objects are safe for concurrent use. Eviction callbacks are invoked after the cache has been unlocked, so
+// they may safely call back into the cache.
+type Cache[K comparable, V any] struct {
+	entries map[K]*list.Element
+	memory  Size
+	mu      sync.Mutex
+	opts    CacheOptions[K, V]
+	order   *list.List
+}
+
+// cacheEntry is a single entry stored in a [Cache] object.
+type cacheEntry[K comparable, V any] struct {
+	expires time.Time
+	key     K
+	size    Size
+	value   V
+}
+
+// cacheEviction records an entry removed from a [Cache] object whose callback has yet to be invoked.
+type cacheEviction[K comparable, V any] struct {
+	entry  *cacheEntry[K, V]
+	reason CacheEvictReason
+}
+
+// NewCache creates a new, empty Cache object with the given options.
+func NewCache[K comparable, V any](opts CacheOptions[K, V]) *Cache[K, V] {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+	return &Cache[K, V]{
+		entries: map[K]*list.Element{},
+		opts:    opts,
+		order:   list.New(),
+	}
+}
+
+// Clear removes all entries from the cache.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	var evicted []cacheEviction[K, V]
+	for e := c.order.Front(); e != nil; e = c.order.Front() {
+		evicted = append(evicted, c.remove(e, CacheEvictDeleted))
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// Delete removes the given key from the cache and returns whether or not it was present.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	e, exists := c.entries[key]
+	var evicted []cacheEviction[K, V]
+	if exists {
+		evicted = append(evicted, c.remove(e, CacheEvictDeleted))
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+	return exists
+}
+
+// Get returns the value stored for the given key and whether or not it was found.
+//
+// A found entry is marked as most recently used. Expired entries are removed and reported as not found.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	var zero V
+	c.mu.Lock()
+	e, exists := c.entries[key]
+	if !exists {
+		c.mu.Unlock()
+		return zero, false
+	}
+	entry := e.Value.(*cacheEntry[K, V])
+	if c.expired(entry) {
+		evicted := []cacheEviction[K, V]{c.remove(e, CacheEvictExpired)}
+		c.mu.Unlock()
+		c.notify(evicted)
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	c.mu.Unlock()
+	return entry.value, true
+}
+
+// Len returns the number of entries in the cache, including any expired entries which have not yet been removed.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Memory returns the total size of the entries in the cache as reported by the options' SizeFunc.
+func (c *Cache[K, V]) Memory() Size {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.memory
+}
+
+// Purge removes all expired entries from the cache and returns the number removed.
+func (c *Cache[K, V]) Purge() int {
+	c.mu.Lock()
+	var evicted []cacheEviction[K, V]
+	for e := c.order.Back(); e != nil; {
+		prev := e.Prev()
+		if c.expired(e.Value.(*cacheEntry[K, V])) {
+			evicted = append(evicted, c.remove(e, CacheEvictExpired))
+		}
+		e = prev
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+	return len(evicted)
+}
+
+// Set stores the value for the given key using the default TTL from the cache options.
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.opts.TTL)
+}
+
+// SetWithTTL stores the value for the given key, expiring it after the given amount of time.
+//
+// A ttl of 0 or less means the entry does not expire. Least recently used entries are evicted as needed to stay
+// within the limits of the cache; an entry which is larger than the maximum memory on its own is evicted immediately.
+func (c *Cache[K, V]) SetWithTTL(key K, value V, ttl Duration) {
+	entry := &cacheEntry[K, V]{key: key, value: value}
+	if ttl > 0 {
+		entry.expires = c.opts.Clock.Now().Add(time.Duration(ttl))
+	}
+	if c.opts.SizeFunc != nil {
+		entry.size = c.opts.SizeFunc(key, value)
+	}
+
+	c.mu.Lock()
+	var evicted []cacheEviction[K, V]
+	if e, exists := c.entries[key]; exists {
+		c.memory -= e.Value.(*cacheEntry[K, V]).size
+		e.Value = entry
+		c.order.MoveToFront(e)
+	} else {
+		c.entries[key] = c.order.PushFront(entry)
+	}
+	c.memory += entry.size
+
+	for e := c.order.Back(); e != nil && c.overLimit(); e = c.order.Back() {
+		evicted = append(evicted, c.remove(e, CacheEvictCapacity))
+	}
+	c.mu.Unlock()
+	c.notify(evicted)
+}
+
+// expired returns whether or not the given entry has passed its expiration time.
+func (c *Cache[K, V]) expired(entry *cacheEntry[K, V]) bool {
+	return !entry.expires.IsZero() && !c.opts.Clock.Now().Before(entry.expires)
+}
+
+// notify invokes the eviction callback for each of the given evicted entries.
+func (c *Cache[K, V]) notify(evicted []cacheEviction[K, V]) {
+	if c.opts.OnEvict == nil {
+		return
+	}
+	for _, ev := range evicted {
+		c.opts.OnEvict(ev.entry.key, ev.entry.value, ev.reason)
+	}
+}
+
+// overLimit returns whether or not the cache currently holds more entries or memory than allowed.
+func (c *Cache[K, V]) overLimit() bool {
+	return (c.opts.MaxEntries > 0 && c.order.Len() > c.opts.MaxEntries) ||
+		(c.opts.MaxMemory > 0 && c.memory > c.opts.MaxMemory)
+}
+
+// remove deletes the given element from the cache and returns a record of the eviction.
+//
+// The cache must be locked when calling this function.
+func (c *Cache[K, V]) remove(e *list.Element, reason CacheEvictReason) cacheEviction[K, V] {
+	entry := c.order.Remove(e).(*cacheEntry[K, V])
+	delete(c.entries, entry.key)
+	c.memory -= entry.size
+	return cacheEviction[K, V]{entry: entry, reason: reason}
+}
@@ -0,0 +1,55 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func TestCache1(t *testing.T) {
+	clock := &manualClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	evictions := map[string]types.CacheEvictReason{}
+	cache := types.NewCache(types.CacheOptions[string, string]{
+		MaxEntries: 2,
+		MaxMemory:  types.Size(10),
+		TTL:        types.Duration(time.Minute),
+		Clock:      clock,
+		OnEvict: func(key, _ string, reason types.CacheEvictReason) {
+			evictions[key] = reason
+		},
+		SizeFunc: func(_, value string) types.Size {
+			return types.Size(len(value))
+		},
+	})
+
+	cache.Set("a", "1234")
+	cache.Set("b", "1234")
+	cache.Get("a")
+	cache.Set("c", "12")
+	if _, ok := cache.Get("b"); ok || evictions["b"] != types.CacheEvictCapacity {
+		t.Errorf("expected least recently used entry to be evicted: %v", evictions)
+	}
+	cache.Set("d", "123456")
+	if cache.Memory() > types.Size(10) {
+		t.Errorf("expected memory to stay within limit but got %s", cache.Memory())
+	}
+
+	cache.SetWithTTL("e", "1", types.Duration(time.Hour))
+	clock.now = clock.now.Add(2 * time.Minute)
+	if n := cache.Purge(); n != 1 {
+		t.Errorf("expected 1 expired entry to be purged but got %d", n)
+	}
+	if v, ok := cache.Get("e"); !ok || v != "1" {
+		t.Errorf("expected entry with longer TTL to remain")
+	}
+	t.Logf("evictions: %v", evictions)
+}
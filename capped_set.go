@@ -0,0 +1,130 @@
+package types
+
+import (
+	"container/list"
+	"fmt"
+)
+
+// EvictionPolicy determines which element is removed from a bounded collection when it is full.
+type EvictionPolicy int
+
+const (
+	// EvictFIFO removes the element which was added first, regardless of how recently it was accessed.
+	EvictFIFO EvictionPolicy = iota
+
+	// EvictLRU removes the element which was least recently added or checked for.
+	EvictLRU
+)
+
+// String returns the [EvictionPolicy] object as a string.
+func (p EvictionPolicy) String() string {
+	switch p {
+	case EvictFIFO:
+		return "fifo"
+	case EvictLRU:
+		return "lru"
+	default:
+		return "unknown"
+	}
+}
+
+// CappedSet is a set of unique elements which holds no more than a maximum number of elements.
+//
+// When an element is added to a full set, an existing element is evicted according to the set's [EvictionPolicy].
+// This makes it suitable for tracking a window of recently seen values, such as event IDs, without unbounded
+// memory growth.
+//
+// CappedSet objects are not safe for concurrent use.
+type CappedSet[E comparable] struct {
+	elems  map[E]*list.Element
+	max    int
+	order  *list.List
+	policy EvictionPolicy
+}
+
+// NewCappedSet creates a new CappedSet object which holds at most max elements.
+//
+// A max of less than 1 is treated as 1.
+func NewCappedSet[E comparable](max int, policy EvictionPolicy) *CappedSet[E] {
+	if max < 1 {
+		max = 1
+	}
+	return &CappedSet[E]{
+		elems:  make(map[E]*list.Element, max),
+		max:    max,
+		order:  list.New(),
+		policy: policy,
+	}
+}
+
+// Add one or more values to the set, evicting existing elements as needed to stay within the maximum size.
+//
+// When the policy is [EvictLRU], adding a value which already exists marks it as most recently used.
+func (s *CappedSet[E]) Add(vals ...E) {
+	for _, v := range vals {
+		if e, exists := s.elems[v]; exists {
+			s.touch(e)
+			continue
+		}
+		if s.order.Len() >= s.max {
+			oldest := s.order.Front()
+			s.order.Remove(oldest)
+			delete(s.elems, oldest.Value.(E))
+		}
+		s.elems[v] = s.order.PushBack(v)
+	}
+}
+
+// Cap returns the maximum number of elements the set can hold.
+func (s *CappedSet[E]) Cap() int {
+	return s.max
+}
+
+// Contains returns whether or not the set contains the given element.
+//
+// When the policy is [EvictLRU], a matching element is marked as most recently used.
+func (s *CappedSet[E]) Contains(val E) bool {
+	e, exists := s.elems[val]
+	if exists {
+		s.touch(e)
+	}
+	return exists
+}
+
+// Len returns the number of elements in the set.
+func (s *CappedSet[E]) Len() int {
+	return s.order.Len()
+}
+
+// Members returns the elements in the set as a slice, ordered from the next element to be evicted to the last.
+func (s *CappedSet[E]) Members() []E {
+	members := make([]E, 0, s.order.Len())
+	for e := s.order.Front(); e != nil; e = e.Next() {
+		members = append(members, e.Value.(E))
+	}
+	return members
+}
+
+// Remove one or more values from the set.
+//
+// Values which do not exist in the set are ignored.
+func (s *CappedSet[E]) Remove(vals ...E) {
+	for _, v := range vals {
+		if e, exists := s.elems[v]; exists {
+			s.order.Remove(e)
+			delete(s.elems, v)
+		}
+	}
+}
+
+// String returns the set formatted as a string.
+func (s *CappedSet[E]) String() string {
+	return fmt.Sprintf("%v", s.Members())
+}
+
+// touch marks the given element as most recently used if the set uses LRU eviction.
+func (s *CappedSet[E]) touch(e *list.Element) {
+	if s.policy == EvictLRU {
+		s.order.MoveToBack(e)
+	}
+}
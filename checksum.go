@@ -0,0 +1,68 @@
+package types
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"strings"
+	"sync"
+)
+
+// ChecksumAlgorithm identifies the hashing algorithm used when computing the checksum of a file.
+type ChecksumAlgorithm string
+
+const (
+	// ChecksumSHA256 computes checksums using SHA-256.
+	ChecksumSHA256 ChecksumAlgorithm = "sha256"
+
+	// ChecksumSHA512 computes checksums using SHA-512.
+	ChecksumSHA512 ChecksumAlgorithm = "sha512"
+)
+
+var (
+	// checksumAlgorithms holds the hash constructors for each of the registered algorithms.
+	checksumAlgorithms = map[ChecksumAlgorithm]func() hash.Hash{
+		ChecksumSHA256: sha256.New,
+		ChecksumSHA512: sha512.New,
+	}
+
+	// checksumAlgorithmsMu guards access to checksumAlgorithms.
+	checksumAlgorithmsMu sync.RWMutex
+)
+
+// RegisterChecksumAlgorithm registers an additional hashing algorithm which can be used to compute checksums.
+//
+// This can be used to add support for algorithms which are not part of the standard library, such as BLAKE2b:
+//
+//	types.RegisterChecksumAlgorithm("blake2b", func() hash.Hash {
+//		h, _ := blake2b.New512(nil)
+//		return h
+//	})
+//
+// Registering an algorithm with the same name as an existing one replaces it.
+func RegisterChecksumAlgorithm(algo ChecksumAlgorithm, newHash func() hash.Hash) {
+	checksumAlgorithmsMu.Lock()
+	defer checksumAlgorithmsMu.Unlock()
+	checksumAlgorithms[algo.normalize()] = newHash
+}
+
+// String returns the [ChecksumAlgorithm] object as a string.
+func (a ChecksumAlgorithm) String() string {
+	return string(a)
+}
+
+// newHash returns a new hash for the algorithm or false if the algorithm has not been registered.
+func (a ChecksumAlgorithm) newHash() (hash.Hash, bool) {
+	checksumAlgorithmsMu.RLock()
+	defer checksumAlgorithmsMu.RUnlock()
+	newHash, ok := checksumAlgorithms[a.normalize()]
+	if !ok {
+		return nil, false
+	}
+	return newHash(), true
+}
+
+// normalize returns the algorithm name in lowercase with any surrounding whitespace or dashes removed.
+func (a ChecksumAlgorithm) normalize() ChecksumAlgorithm {
+	return ChecksumAlgorithm(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(string(a))), "-", ""))
+}
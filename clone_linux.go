@@ -0,0 +1,20 @@
+//go:build linux
+
+package types
+
+import (
+	"os"
+	"syscall"
+)
+
+// ficlone is the FICLONE ioctl request number.
+const ficlone = 0x40049409
+
+// cloneFile makes dest a reflink of src so that both files share the same data blocks.
+func cloneFile(src, dest *os.File) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, dest.Fd(), ficlone, src.Fd())
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package types
+
+import (
+	"errors"
+	"os"
+)
+
+// cloneFile is not supported on this platform.
+func cloneFile(src, dest *os.File) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Compression identifies the compression format used when reading and writing files.
+type Compression string
+
+const (
+	// CompressionNone indicates that files are read and written as-is.
+	CompressionNone Compression = ""
+
+	// CompressionGzip indicates that files are compressed using gzip.
+	CompressionGzip Compression = "gzip"
+)
+
+// compressionCodec holds the functions used to compress and decompress data for a compression format.
+type compressionCodec struct {
+	newReader func(io.Reader) (io.ReadCloser, error)
+	newWriter func(io.Writer) (io.WriteCloser, error)
+}
+
+var (
+	// compressionCodecs holds the codecs for each of the registered compression formats.
+	compressionCodecs = map[Compression]compressionCodec{
+		CompressionGzip: {
+			newReader: func(r io.Reader) (io.ReadCloser, error) {
+				return gzip.NewReader(r)
+			},
+			newWriter: func(w io.Writer) (io.WriteCloser, error) {
+				return gzip.NewWriter(w), nil
+			},
+		},
+	}
+
+	// compressionCodecsMu guards access to compressionCodecs.
+	compressionCodecsMu sync.RWMutex
+)
+
+// RegisterCompression registers an additional compression format which can be used when reading and writing files.
+//
+// This can be used to add support for formats which are not part of the standard library, such as zstd:
+//
+//	types.RegisterCompression("zstd",
+//		func(r io.Reader) (io.ReadCloser, error) {
+//			d, err := zstd.NewReader(r)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return d.IOReadCloser(), nil
+//		},
+//		func(w io.Writer) (io.WriteCloser, error) {
+//			return zstd.NewWriter(w)
+//		})
+//
+// Registering a format with the same name as an existing one replaces it.
+func RegisterCompression(c Compression, newReader func(io.Reader) (io.ReadCloser, error),
+	newWriter func(io.Writer) (io.WriteCloser, error)) {
+	compressionCodecsMu.Lock()
+	defer compressionCodecsMu.Unlock()
+	compressionCodecs[c.normalize()] = compressionCodec{
+		newReader: newReader,
+		newWriter: newWriter,
+	}
+}
+
+// String returns the [Compression] object as a string.
+func (c Compression) String() string {
+	return string(c)
+}
+
+// codec returns the codec for the compression format.
+func (c Compression) codec() (compressionCodec, error) {
+	compressionCodecsMu.RLock()
+	defer compressionCodecsMu.RUnlock()
+	codec, ok := compressionCodecs[c.normalize()]
+	if !ok {
+		return compressionCodec{}, fmt.Errorf("unsupported compression format '%s'", c)
+	}
+	return codec, nil
+}
+
+// normalize returns the format name in lowercase with any surrounding whitespace removed.
+func (c Compression) normalize() Compression {
+	return Compression(strings.ToLower(strings.TrimSpace(string(c))))
+}
+
+// wrap returns a [File] which compresses data written to the given file or decompresses data read from it,
+// depending on whether the flags indicate the file was opened for writing or reading.
+func (c Compression) wrap(file File, flags int) (File, error) {
+	codec, err := c.codec()
+	if err != nil {
+		return nil, err
+	}
+	switch flags & (os.O_RDONLY | os.O_WRONLY | os.O_RDWR) {
+	case os.O_RDONLY:
+		r, err := codec.newReader(file)
+		if err != nil {
+			return nil, err
+		}
+		return &compressedFile{File: file, r: r}, nil
+	case os.O_WRONLY:
+		w, err := codec.newWriter(file)
+		if err != nil {
+			return nil, err
+		}
+		return &compressedFile{File: file, w: w}, nil
+	}
+	return nil, errors.New("compressed files must be opened for either reading or writing, but not both")
+}
+
+// compressedFile is a [File] which transparently compresses data written to it or decompresses data read from it.
+type compressedFile struct {
+	File
+	r io.ReadCloser
+	w io.WriteCloser
+}
+
+// Close flushes any compressed data and closes the underlying file.
+func (f *compressedFile) Close() error {
+	var err error
+	if f.w != nil {
+		err = f.w.Close()
+	}
+	if f.r != nil {
+		err = f.r.Close()
+	}
+	return errors.Join(err, f.File.Close())
+}
+
+// Read reads and decompresses data from the file.
+func (f *compressedFile) Read(p []byte) (int, error) {
+	if f.r == nil {
+		return 0, &fs.PathError{Op: "read", Path: f.Name(), Err: errors.ErrUnsupported}
+	}
+	return f.r.Read(p)
+}
+
+// Seek always fails since compressed files cannot be seeked.
+func (f *compressedFile) Seek(int64, int) (int64, error) {
+	return 0, &fs.PathError{Op: "seek", Path: f.Name(), Err: errors.ErrUnsupported}
+}
+
+// Sync flushes any compressed data and commits the contents of the file to stable storage.
+func (f *compressedFile) Sync() error {
+	if flusher, ok := f.w.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return err
+		}
+	}
+	return f.File.Sync()
+}
+
+// Write compresses and writes data to the file.
+func (f *compressedFile) Write(p []byte) (int, error) {
+	if f.w == nil {
+		return 0, &fs.PathError{Op: "write", Path: f.Name(), Err: errors.ErrUnsupported}
+	}
+	return f.w.Write(p)
+}
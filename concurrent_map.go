@@ -0,0 +1,257 @@
+package types
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"iter"
+	"maps"
+	"math"
+	"reflect"
+	"sync"
+)
+
+// defaultConcurrentMapShards is the number of shards used when creating a [ConcurrentMap] object with no shard count.
+const defaultConcurrentMapShards = 32
+
+// ConcurrentMap is a generic map which is safe for concurrent use.
+//
+// Keys are spread across a number of shards, each with its own lock, so that writers to different keys rarely
+// contend with one another. Unlike [sync.Map], it is type-safe and does not favor read-mostly workloads.
+//
+// Strings, integers and floats are hashed without reflection. Other key types, such as structs and arrays, are hashed field by
+// field using reflection, which is noticeably slower.
+type ConcurrentMap[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []concurrentMapShard[K, V]
+}
+
+// concurrentMapShard is a single locked partition of a [ConcurrentMap] object.
+type concurrentMapShard[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  map[K]V
+}
+
+// NewConcurrentMap creates a new, empty ConcurrentMap object with the given number of shards.
+//
+// If shards is less than 1, a default of 32 shards is used.
+func NewConcurrentMap[K comparable, V any](shards int) *ConcurrentMap[K, V] {
+	if shards < 1 {
+		shards = defaultConcurrentMapShards
+	}
+	m := &ConcurrentMap[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]concurrentMapShard[K, V], shards),
+	}
+	for i := range m.shards {
+		m.shards[i].m = map[K]V{}
+	}
+	return m
+}
+
+// All returns an iterator over a snapshot of the key/value pairs in the map in no particular order.
+//
+// Each shard is copied while it is locked, so the map may be modified while iterating without affecting the
+// iteration or blocking other callers.
+func (m *ConcurrentMap[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(m.Snapshot())
+}
+
+// Compute atomically updates the value for the given key.
+//
+// The function is called with the current value and whether or not the key exists while the key's shard is locked.
+// It returns the new value and whether or not the key should be kept; if keep is false, the key is deleted. Compute
+// returns the resulting value and whether or not the key is present afterwards.
+//
+// The function must not call other methods on the map.
+func (m *ConcurrentMap[K, V]) Compute(key K, fn func(value V, exists bool) (newValue V, keep bool)) (V, bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, exists := s.m[key]
+	value, keep := fn(value, exists)
+	if !keep {
+		delete(s.m, key)
+		var zero V
+		return zero, false
+	}
+	s.m[key] = value
+	return value, true
+}
+
+// Delete removes the given key from the map and returns whether or not it was present.
+func (m *ConcurrentMap[K, V]) Delete(key K) bool {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, exists := s.m[key]
+	delete(s.m, key)
+	return exists
+}
+
+// Get returns the value stored for the given key and whether or not it was found.
+func (m *ConcurrentMap[K, V]) Get(key K) (V, bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, exists := s.m[key]
+	return value, exists
+}
+
+// GetOrSet returns the existing value for the given key if it is present. Otherwise, it stores and returns the given
+// value. The loaded result is true if the value was already present.
+func (m *ConcurrentMap[K, V]) GetOrSet(key K, value V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, exists := s.m[key]; exists {
+		return existing, true
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// Len returns the number of entries in the map.
+//
+// Since shards are counted one at a time, the result may be stale if the map is being modified concurrently.
+func (m *ConcurrentMap[K, V]) Len() int {
+	n := 0
+	for i := range m.shards {
+		m.shards[i].mu.RLock()
+		n += len(m.shards[i].m)
+		m.shards[i].mu.RUnlock()
+	}
+	return n
+}
+
+// Set stores the value for the given key.
+func (m *ConcurrentMap[K, V]) Set(key K, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.m[key] = value
+}
+
+// Snapshot returns a copy of the entries in the map.
+//
+// Each shard is copied while it is locked but shards are not locked together, so the snapshot is not a consistent
+// view of the whole map if it is being modified concurrently.
+func (m *ConcurrentMap[K, V]) Snapshot() map[K]V {
+	result := map[K]V{}
+	for i := range m.shards {
+		m.shards[i].mu.RLock()
+		maps.Copy(result, m.shards[i].m)
+		m.shards[i].mu.RUnlock()
+	}
+	return result
+}
+
+// shard returns the shard responsible for the given key.
+//
+// Keys which are equal according to == must always hash to the same shard. Common key types are hashed without
+// reflection and any other key is hashed field by field using reflection by writeConcurrentMapKey.
+func (m *ConcurrentMap[K, V]) shard(key K) *concurrentMapShard[K, V] {
+	var n uint64
+	switch k := any(key).(type) {
+	case string:
+		return m.shardFor(maphash.String(m.seed, k))
+	case int:
+		n = uint64(k)
+	case int64:
+		n = uint64(k)
+	case int32:
+		n = uint64(k)
+	case uint:
+		n = uint64(k)
+	case uint64:
+		n = k
+	case uint32:
+		n = uint64(k)
+	case float64:
+		n = concurrentMapFloatBits(k)
+	case float32:
+		n = concurrentMapFloatBits(float64(k))
+	default:
+		var mh maphash.Hash
+		mh.SetSeed(m.seed)
+		writeConcurrentMapKey(&mh, reflect.ValueOf(k))
+		return m.shardFor(mh.Sum64())
+	}
+
+	// mix numbers through the hash rather than using them directly, otherwise keys which share their low bits, such
+	// as multiples of the shard count or whole floats, would all share a shard
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], n)
+	return m.shardFor(maphash.Bytes(m.seed, buf[:]))
+}
+
+// shardFor returns the shard which holds keys with the given hash.
+func (m *ConcurrentMap[K, V]) shardFor(h uint64) *concurrentMapShard[K, V] {
+	return &m.shards[h%uint64(len(m.shards))]
+}
+
+// concurrentMapFloatBits returns the bits of the given float with -0 and +0 treated as the same value.
+func concurrentMapFloatBits(f float64) uint64 {
+	if f == 0 {
+		f = 0
+	}
+	return math.Float64bits(f)
+}
+
+// writeConcurrentMapKey writes the given comparable value to the hash so that values which are equal according to ==
+// produce the same hash.
+//
+// Structs and arrays are hashed by their fields and elements, interfaces by their dynamic value and pointers and
+// channels by their address.
+func writeConcurrentMapKey(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint64 := func(n uint64) {
+		binary.LittleEndian.PutUint64(buf[:], n)
+		h.Write(buf[:])
+	}
+
+	if !v.IsValid() {
+		h.WriteByte(0)
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint64(concurrentMapFloatBits(v.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeUint64(concurrentMapFloatBits(real(c)))
+		writeUint64(concurrentMapFloatBits(imag(c)))
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(uint64(v.Pointer()))
+	case reflect.Interface:
+		writeConcurrentMapKey(h, v.Elem())
+	case reflect.Array:
+		for i := range v.Len() {
+			writeConcurrentMapKey(h, v.Index(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := range v.NumField() {
+			// blank fields are ignored when comparing structs
+			if t.Field(i).Name != "_" {
+				writeConcurrentMapKey(h, v.Field(i))
+			}
+		}
+	}
+}
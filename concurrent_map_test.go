@@ -0,0 +1,112 @@
+package types_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestConcurrentMap1(t *testing.T) {
+	counts := types.NewConcurrentMap[string, int](8)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for _, key := range []string{"a", "b", "c"} {
+				counts.Compute(key, func(n int, _ bool) (int, bool) {
+					return n + 1, true
+				})
+			}
+			counts.GetOrSet("first", i)
+		}(i)
+	}
+	wg.Wait()
+
+	snapshot := counts.Snapshot()
+	if snapshot["a"] != 50 || snapshot["b"] != 50 || snapshot["c"] != 50 || counts.Len() != 4 {
+		t.Errorf("unexpected counts: %v", snapshot)
+	}
+	if _, loaded := counts.GetOrSet("first", -1); !loaded {
+		t.Errorf("expected existing value to be loaded")
+	}
+	if _, present := counts.Compute("a", func(int, bool) (int, bool) { return 0, false }); present {
+		t.Errorf("expected computed key to be deleted")
+	}
+	for key, value := range counts.All() {
+		t.Logf("%s = %d", key, value)
+	}
+}
+
+func TestConcurrentMap2(t *testing.T) {
+	type point struct {
+		X, Y float32
+		Name string
+	}
+	negZero := float32(math.Copysign(0, -1))
+
+	m := types.NewConcurrentMap[point, int](64)
+	for i := 0; i < 100; i++ {
+		m.Set(point{X: negZero, Y: float32(i), Name: "p"}, i)
+		m.Set(point{X: 0, Y: float32(i), Name: "p"}, i)
+	}
+	if m.Len() != 100 {
+		t.Errorf("expected equal keys to share an entry but found %d entries", m.Len())
+	}
+
+	floats := types.NewConcurrentMap[float32, int](64)
+	floats.Set(negZero, 1)
+	floats.Set(0, 2)
+	if value, _ := floats.Get(negZero); floats.Len() != 1 || value != 2 {
+		t.Errorf("expected -0 and 0 to be the same key")
+	}
+
+	ifaces := types.NewConcurrentMap[any, int](64)
+	ifaces.Set([2]any{negZero, "a"}, 1)
+	ifaces.Set([2]any{float32(0), "a"}, 2)
+	ifaces.Set(nil, 3)
+	if ifaces.Len() != 2 {
+		t.Errorf("expected 2 interface keys but found %d", ifaces.Len())
+	}
+	t.Logf("entries: %v", m.Len()+floats.Len()+ifaces.Len())
+}
+
+func TestConcurrentMap3(t *testing.T) {
+	// keys which are all multiples of the shard count must still be spread across the shards, so while the shard
+	// holding one key is locked, writes to at least some of the others can complete
+	m := types.NewConcurrentMap[int, int](32)
+	m.Compute(0, func(int, bool) (int, bool) {
+		done := make(chan int, 8)
+		for i := 1; i <= 8; i++ {
+			go func(key int) {
+				m.Set(key, key)
+				done <- key
+			}(i * 32)
+		}
+		select {
+		case key := <-done:
+			t.Logf("key %d was written while key 0 was locked", key)
+		case <-time.After(time.Second):
+			t.Errorf("expected keys to be spread across shards but every write was blocked")
+		}
+		return 0, true
+	})
+}
+
+func BenchmarkConcurrentMapStructKey(b *testing.B) {
+	type key struct {
+		ID     int
+		Region string
+	}
+	m := types.NewConcurrentMap[key, int](0)
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Set(key{ID: i % 1024, Region: "us-east"}, i)
+			i++
+		}
+	})
+}
@@ -0,0 +1,237 @@
+package types
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date represents a calendar date with no time of day or time zone, such as "2024-06-01".
+//
+// The zero value is year 0, which is treated as an unset date.
+type Date struct {
+	// Year is the year, such as 2024.
+	Year int
+
+	// Month is the month of the year.
+	Month time.Month
+
+	// Day is the day of the month, starting at 1.
+	Day int
+}
+
+// DateOf returns the date on which the given time falls in its own location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d}
+}
+
+// ParseDate parses the given string in "YYYY-MM-DD" format into a [Date] object.
+//
+// If an empty string is supplied, the zero value is returned.
+func ParseDate(date string) (Date, error) {
+	str := strings.TrimSpace(date)
+	if str == "" {
+		return Date{}, nil
+	}
+	t, err := time.Parse(time.DateOnly, str)
+	if err != nil {
+		return Date{}, fmt.Errorf("failed to parse date '%s': %w", date, err)
+	}
+	return DateOf(t), nil
+}
+
+// AddDays returns the date the given number of days after the date. Negative values move backwards.
+func (d Date) AddDays(days int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, days))
+}
+
+// After returns whether or not the date is after the given date.
+func (d Date) After(d2 Date) bool {
+	return d.Compare(d2) > 0
+}
+
+// Before returns whether or not the date is before the given date.
+func (d Date) Before(d2 Date) bool {
+	return d.Compare(d2) < 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether the date is before, the same as or after the given date.
+func (d Date) Compare(d2 Date) int {
+	if c := cmp.Compare(d.Year, d2.Year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(d.Month, d2.Month); c != 0 {
+		return c
+	}
+	return cmp.Compare(d.Day, d2.Day)
+}
+
+// In returns the time at midnight at the start of the date in the given location.
+//
+// If loc is nil, [time.Local] is used.
+func (d Date) In(loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// IsZero returns whether or not the date is unset.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// MarshalJSON marshals the [Date] object to JSON.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// MarshalText marshals the [Date] object to plain text.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// String returns the [Date] object as a string in "YYYY-MM-DD" format or an empty string if it is unset.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// UnmarshalJSON parses the JSON data into a [Date] object.
+//
+// If an empty string is supplied, the zero value is stored.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [Date] object.
+//
+// If an empty string is supplied, the zero value is stored.
+func (d *Date) UnmarshalText(data []byte) error {
+	date, err := ParseDate(string(data))
+	if err != nil {
+		return err
+	}
+	*d = date
+	return nil
+}
+
+// TimeOfDay represents a wall-clock time with no date or time zone, such as "14:30" or "14:30:15".
+//
+// The zero value is midnight.
+type TimeOfDay struct {
+	// Hour is the hour of the day, from 0 to 23.
+	Hour int
+
+	// Minute is the minute of the hour, from 0 to 59.
+	Minute int
+
+	// Second is the second of the minute, from 0 to 59.
+	Second int
+}
+
+// ParseTimeOfDay parses the given string in "HH:MM" or "HH:MM:SS" 24-hour format into a [TimeOfDay] object.
+//
+// If an empty string is supplied, midnight is returned.
+func ParseTimeOfDay(tod string) (TimeOfDay, error) {
+	str := strings.TrimSpace(tod)
+	if str == "" {
+		return TimeOfDay{}, nil
+	}
+	layout := "15:04"
+	if strings.Count(str, ":") == 2 {
+		layout = time.TimeOnly
+	}
+	t, err := time.Parse(layout, str)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("failed to parse time of day '%s': %w", tod, err)
+	}
+	return TimeOfDayOf(t), nil
+}
+
+// TimeOfDayOf returns the wall-clock time of the given time in its own location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second()}
+}
+
+// After returns whether or not the time of day is after the given time of day.
+func (t TimeOfDay) After(t2 TimeOfDay) bool {
+	return t.Compare(t2) > 0
+}
+
+// Before returns whether or not the time of day is before the given time of day.
+func (t TimeOfDay) Before(t2 TimeOfDay) bool {
+	return t.Compare(t2) < 0
+}
+
+// Compare returns -1, 0 or +1 depending on whether the time of day is before, the same as or after the given time
+// of day.
+func (t TimeOfDay) Compare(t2 TimeOfDay) int {
+	return cmp.Compare(t.seconds(), t2.seconds())
+}
+
+// MarshalJSON marshals the [TimeOfDay] object to JSON.
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// MarshalText marshals the [TimeOfDay] object to plain text.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// On returns the time at which the time of day occurs on the given date in the given location.
+//
+// If loc is nil, [time.Local] is used. Times which fall in a daylight saving time gap are normalized by
+// [time.Date].
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.Local
+	}
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, 0, loc)
+}
+
+// String returns the [TimeOfDay] object as a string in "HH:MM" format, or "HH:MM:SS" format if it has seconds.
+func (t TimeOfDay) String() string {
+	if t.Second != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	}
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+// UnmarshalJSON parses the JSON data into a [TimeOfDay] object.
+//
+// If an empty string is supplied, midnight is stored.
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [TimeOfDay] object.
+//
+// If an empty string is supplied, midnight is stored.
+func (t *TimeOfDay) UnmarshalText(data []byte) error {
+	tod, err := ParseTimeOfDay(string(data))
+	if err != nil {
+		return err
+	}
+	*t = tod
+	return nil
+}
+
+// seconds returns the number of seconds since midnight.
+func (t TimeOfDay) seconds() int {
+	return t.Hour*3600 + t.Minute*60 + t.Second
+}
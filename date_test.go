@@ -0,0 +1,46 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestDate1(t *testing.T) {
+	var window struct {
+		Day   types.Date      `json:"day"`
+		Start types.TimeOfDay `json:"start"`
+		End   types.TimeOfDay `json:"end"`
+	}
+	if err := json.Unmarshal([]byte(`{"day": "2024-02-28", "start": "22:30", "end": "23:59:30"}`), &window); err != nil {
+		t.Fatalf("failed to unmarshal maintenance window: %v", err)
+	}
+	if next := window.Day.AddDays(2); next.String() != "2024-03-01" || !next.After(window.Day) {
+		t.Errorf("unexpected date after adding days: %s", next)
+	}
+	if !window.Start.Before(window.End) {
+		t.Errorf("expected %s to be before %s", window.Start, window.End)
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	start := window.Start.On(window.Day, loc)
+	if start.UTC().Format(time.RFC3339) != "2024-02-29T03:30:00Z" {
+		t.Errorf("unexpected start time: %s", start)
+	}
+	out, _ := json.Marshal(window)
+	t.Logf("window: %s", out)
+
+	for _, str := range []string{"2024-13-01", "24:00", "9:5"} {
+		if _, err := types.ParseDate(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse as a date", str)
+		}
+	}
+	if _, err := types.ParseTimeOfDay("24:00"); err == nil {
+		t.Errorf("expected '24:00' to fail to parse as a time of day")
+	}
+}
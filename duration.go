@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -13,56 +14,307 @@ import (
 // months, weeks, days and years using the suffixes "mo", "w", "d" and "y" respectively.
 //
 // A month is defined as 30 days, a week is defined as 7 days, a day is defined as 24 hours and a year is
-// defined as 365 days.
+// defined as 365 days. Extended and standard units may be combined in a single duration, e.g. "2w3d" or "1d12h30m".
 //
 // It also supports unmarshaling empty strings to an empty [Duration] object.
 type Duration time.Duration
 
+// Extended units of time which can be used with [Duration.Round] and [Duration.Truncate] or to convert an integer
+// number of units into a [Duration] object, e.g. 3 * types.Day.
+const (
+	Day   = Duration(24 * time.Hour)
+	Week  = 7 * Day
+	Month = 30 * Day
+	Year  = 365 * Day
+)
+
+// extendedDurationUnits holds the length of each of the extended units supported by [ParseDuration].
+var extendedDurationUnits = map[string]time.Duration{
+	"y":  time.Duration(Year),
+	"mo": time.Duration(Month),
+	"w":  time.Duration(Week),
+	"d":  time.Duration(Day),
+}
+
+// DurationFormatOptions holds the settings used when formatting a [Duration] object with [Duration.Humanize].
+type DurationFormatOptions struct {
+	// Compact indicates if abbreviated unit names should be used without spaces, e.g. "2d3h" rather than
+	// "2 days 3 hours".
+	Compact bool `json:"compact" yaml:"compact" mapstructure:"compact"`
+
+	// MaxUnits is the maximum number of units to display, starting with the largest. The duration is rounded to the
+	// smallest unit displayed. If 0 or less, all units are displayed.
+	MaxUnits int `json:"max_units" yaml:"max_units" mapstructure:"max_units"`
+
+	// Resolution is the smallest amount of time to display. The duration is rounded to a multiple of it before being
+	// formatted. If 0 or less, the duration is displayed down to the nanosecond.
+	Resolution Duration `json:"resolution" yaml:"resolution" mapstructure:"resolution"`
+}
+
+// durationUnits holds the units used when humanizing durations, from largest to smallest.
+var durationUnits = []struct {
+	size    time.Duration
+	name    string
+	compact string
+}{
+	{365 * 24 * time.Hour, "year", "y"},
+	{30 * 24 * time.Hour, "month", "mo"},
+	{7 * 24 * time.Hour, "week", "w"},
+	{24 * time.Hour, "day", "d"},
+	{time.Hour, "hour", "h"},
+	{time.Minute, "minute", "m"},
+	{time.Second, "second", "s"},
+	{time.Millisecond, "millisecond", "ms"},
+	{time.Microsecond, "microsecond", "µs"},
+	{time.Nanosecond, "nanosecond", "ns"},
+}
+
+// ParseDuration parses the given string into a [Duration] object.
+//
+// In addition to the formats accepted by [time.ParseDuration] and the extended suffixes described by [Duration], ISO
+// 8601 durations such as "P1Y2M3DT4H5M" are accepted (see [ParseISO8601Duration]).
+//
+// If an empty string is supplied, 0 is returned.
 func ParseDuration(dur string) (Duration, error) {
 	// empty duration
 	if dur == "" {
 		return Duration(0), nil
 	}
+	if strings.HasPrefix(strings.TrimLeft(dur, "+-"), "P") {
+		return ParseISO8601Duration(dur)
+	}
 
-	// convert new suffixes to supported [time.Duration] suffix
-	var hourPeriod int64
-	if strings.HasSuffix(dur, "mo") {
-		dur = strings.TrimSuffix(dur, "mo")
-		hourPeriod = int64(30 /* days */ * 24 /* hours */)
-	} else if strings.HasSuffix(dur, "w") {
-		dur = strings.TrimSuffix(dur, "w")
-		hourPeriod = int64(7 /* days */ * 24 /* hours */)
-	} else if strings.HasSuffix(dur, "d") {
-		dur = strings.TrimSuffix(dur, "d")
-		hourPeriod = int64(24 /* hours */)
-	} else if strings.HasSuffix(dur, "y") {
-		dur = strings.TrimSuffix(dur, "y")
-		hourPeriod = int64(365 /* days */ * 24 /* hours */)
-	}
-	if hourPeriod > 0 {
-		val, err := strconv.ParseInt(dur, 10, 64)
+	// split the extended units from the standard units, which are parsed by [time.ParseDuration]
+	str, negative := dur, false
+	if str[0] == '-' || str[0] == '+' {
+		str, negative = str[1:], str[0] == '-'
+	}
+	var extended time.Duration
+	var standard strings.Builder
+	for str != "" {
+		numEnd := strings.IndexFunc(str, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.'
+		})
+		if numEnd < 0 {
+			standard.WriteString(str)
+			break
+		}
+		unitEnd := strings.IndexFunc(str[numEnd:], func(r rune) bool {
+			return (r >= '0' && r <= '9') || r == '.'
+		})
+		if unitEnd < 0 {
+			unitEnd = len(str) - numEnd
+		}
+		unitEnd += numEnd
+		unit, ok := extendedDurationUnits[str[numEnd:unitEnd]]
+		if !ok {
+			standard.WriteString(str[:unitEnd])
+			str = str[unitEnd:]
+			continue
+		}
+		val, err := parseDurationUnits(str[:numEnd], unit)
 		if err != nil {
-			return 0, fmt.Errorf("failed to parse integer portion of duration '%s': %w", dur, err)
+			return 0, fmt.Errorf("failed to parse duration '%s': %w", dur, err)
 		}
-		if val > math.MaxInt64/hourPeriod {
+		if extended > math.MaxInt64-val {
 			return 0, fmt.Errorf("duration '%s' exceeds maximum size of a 64-bit integer", dur)
 		}
-		dur = fmt.Sprintf("%dh", val*hourPeriod)
+		extended += val
+		str = str[unitEnd:]
+	}
+
+	// parse the standard units and combine them with the extended units
+	var parsedDuration time.Duration
+	if standard.Len() > 0 {
+		var err error
+		if parsedDuration, err = time.ParseDuration(standard.String()); err != nil {
+			return 0, fmt.Errorf("failed to parse duration '%s': %w", dur, err)
+		}
+	}
+	if extended > math.MaxInt64-parsedDuration {
+		return 0, fmt.Errorf("duration '%s' exceeds maximum size of a 64-bit integer", dur)
+	}
+	parsedDuration += extended
+	if negative {
+		parsedDuration = -parsedDuration
+	}
+	return Duration(parsedDuration), nil
+}
+
+// ParseISO8601Duration parses the given ISO 8601 duration, such as "P1Y2M3DT4H5M6.5S" or "PT15M", into a [Duration]
+// object.
+//
+// Years, months, weeks and days are converted using the same definitions as the extended suffixes described by
+// [Duration]. Any component may include a fractional part and the duration may be preceded by a "-" sign.
+func ParseISO8601Duration(dur string) (Duration, error) {
+	str := dur
+	sign := 1.0
+	if strings.HasPrefix(str, "-") {
+		sign, str = -1, str[1:]
+	} else {
+		str = strings.TrimPrefix(str, "+")
+	}
+	if !strings.HasPrefix(str, "P") || len(str) < 2 || strings.HasSuffix(str, "T") {
+		return 0, fmt.Errorf("failed to parse ISO 8601 duration '%s': invalid format", dur)
+	}
+	str = str[1:]
+
+	var total float64
+	inTime := false
+	for str != "" {
+		if str[0] == 'T' {
+			if inTime {
+				return 0, fmt.Errorf("failed to parse ISO 8601 duration '%s': invalid format", dur)
+			}
+			inTime, str = true, str[1:]
+			continue
+		}
+		end := strings.IndexFunc(str, func(r rune) bool {
+			return (r < '0' || r > '9') && r != '.' && r != ','
+		})
+		if end <= 0 {
+			return 0, fmt.Errorf("failed to parse ISO 8601 duration '%s': invalid format", dur)
+		}
+		val, err := strconv.ParseFloat(strings.Replace(str[:end], ",", ".", 1), 64)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse ISO 8601 duration '%s': %w", dur, err)
+		}
+		var unit time.Duration
+		switch designator := str[end]; {
+		case !inTime && designator == 'Y':
+			unit = 365 * 24 * time.Hour
+		case !inTime && designator == 'M':
+			unit = 30 * 24 * time.Hour
+		case !inTime && designator == 'W':
+			unit = 7 * 24 * time.Hour
+		case !inTime && designator == 'D':
+			unit = 24 * time.Hour
+		case inTime && designator == 'H':
+			unit = time.Hour
+		case inTime && designator == 'M':
+			unit = time.Minute
+		case inTime && designator == 'S':
+			unit = time.Second
+		default:
+			return 0, fmt.Errorf("failed to parse ISO 8601 duration '%s': unexpected designator '%c'", dur,
+				designator)
+		}
+		total += val * float64(unit)
+		str = str[end+1:]
+	}
+	if total > math.MaxInt64 {
+		return 0, fmt.Errorf("duration '%s' exceeds maximum size of a 64-bit integer", dur)
+	}
+	return Duration(sign * total), nil
+}
+
+// Humanize returns the [Duration] object as a string which is easy for people to read, such as "2 days 3 hours" or
+// "2d3h" rather than "51h0m0s".
+//
+// Years, months and weeks are used for long durations using the same definitions described by [Duration], so
+// "P45D" is formatted as "1 month 2 weeks 1 day". Units with a value of 0 are omitted.
+func (d Duration) Humanize(opts DurationFormatOptions) string {
+	dur := time.Duration(d)
+	sign := ""
+	if dur < 0 {
+		sign, dur = "-", -dur
+	}
+	if opts.Resolution > 0 {
+		dur = dur.Round(time.Duration(opts.Resolution))
+	}
+
+	// round to the smallest unit which will be displayed
+	if opts.MaxUnits > 0 {
+		for i, unit := range durationUnits {
+			if dur >= unit.size {
+				last := min(i+opts.MaxUnits-1, len(durationUnits)-1)
+				dur = dur.Round(durationUnits[last].size)
+				break
+			}
+		}
 	}
 
-	// parse duration by hours
-	parsedDuration, err := time.ParseDuration(dur)
-	return Duration(parsedDuration), err
+	var parts []string
+	for _, unit := range durationUnits {
+		if dur < unit.size || (opts.MaxUnits > 0 && len(parts) == opts.MaxUnits) {
+			continue
+		}
+		count := dur / unit.size
+		dur -= count * unit.size
+		parts = append(parts, formatDurationUnit(int64(count), unit.name, unit.compact, opts.Compact))
+	}
+	if len(parts) == 0 {
+		// display 0 using the unit of the resolution, or seconds by default
+		resolution := max(time.Duration(opts.Resolution), time.Second)
+		for _, unit := range durationUnits {
+			if unit.size <= resolution {
+				return formatDurationUnit(0, unit.name, unit.compact, opts.Compact)
+			}
+		}
+	}
+	if opts.Compact {
+		return sign + strings.Join(parts, "")
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// ISO8601String returns the [Duration] object as an ISO 8601 duration, such as "P1DT2H30M".
+//
+// Since the length of years and months varies, the largest unit used is days.
+func (d Duration) ISO8601String() string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var sb strings.Builder
+	dur := time.Duration(d)
+	if dur < 0 {
+		sb.WriteByte('-')
+		dur = -dur
+	}
+	sb.WriteByte('P')
+	day := 24 * time.Hour
+	if days := dur / day; days > 0 {
+		fmt.Fprintf(&sb, "%dD", days)
+		dur -= days * day
+	}
+	if dur > 0 {
+		sb.WriteByte('T')
+		if hours := dur / time.Hour; hours > 0 {
+			fmt.Fprintf(&sb, "%dH", hours)
+			dur -= hours * time.Hour
+		}
+		if minutes := dur / time.Minute; minutes > 0 {
+			fmt.Fprintf(&sb, "%dM", minutes)
+			dur -= minutes * time.Minute
+		}
+		if dur > 0 {
+			sb.WriteString(strconv.FormatFloat(dur.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+	return sb.String()
 }
 
 // MarshalJSON marshals the [Duration] object to JSON.
+//
+// Durations of at least 1 day are marshaled using the extended suffixes so that they are written back to
+// configuration files in the same form they were most likely read, e.g. "30d" rather than "720h0m0s" or "1d12h0m0s"
+// rather than "36h0m0s". Years are used if the duration is a whole number of years and days are used otherwise.
 func (d Duration) MarshalJSON() ([]byte, error) {
-	return json.Marshal(d.String())
+	return json.Marshal(d.marshalString())
 }
 
 // MarshalText marshals the [Duration] object to plain text.
+//
+// See [Duration.MarshalJSON] for details on the format used.
 func (d Duration) MarshalText() ([]byte, error) {
-	return json.Marshal(d.String())
+	return []byte(d.marshalString()), nil
+}
+
+// Round returns the result of rounding the duration to the nearest multiple of m, such as [Day] or [Week].
+//
+// Halfway values are rounded away from zero. If m is 0 or less, the duration is returned unchanged.
+func (d Duration) Round(m Duration) Duration {
+	return Duration(time.Duration(d).Round(time.Duration(m)))
 }
 
 // String returns the [Duration] object as a string.
@@ -70,6 +322,16 @@ func (d Duration) String() string {
 	return time.Duration(d).String()
 }
 
+// Truncate returns the result of rounding the duration toward zero to a multiple of m, such as [Day] or [Week].
+//
+// If m is 0 or less, the duration is returned unchanged.
+func (d Duration) Truncate(m Duration) Duration {
+	if m <= 0 {
+		return d
+	}
+	return d - d%m
+}
+
 // UnmarshalJSON parses the JSON data into a [Duration] object.
 //
 // If an empty string is supplied, 0 is stored.
@@ -103,3 +365,54 @@ func (d *Duration) UnmarshalText(data []byte) error {
 	*d = dur
 	return nil
 }
+
+// marshalString returns the [Duration] object as a string which can be parsed by [ParseDuration], using the extended
+// suffixes for durations of at least 1 day.
+func (d Duration) marshalString() string {
+	sign, abs := "", d
+	if d < 0 {
+		sign, abs = "-", -d
+	}
+	switch {
+	case abs < Day:
+		return d.String()
+	case abs%Year == 0:
+		return sign + strconv.FormatInt(int64(abs/Year), 10) + "y"
+	case abs%Day == 0:
+		return sign + strconv.FormatInt(int64(abs/Day), 10) + "d"
+	}
+	return sign + strconv.FormatInt(int64(abs/Day), 10) + "d" + (abs % Day).String()
+}
+
+// parseDurationUnits converts the given number of units of the given length into a [time.Duration] object.
+func parseDurationUnits(value string, unit time.Duration) (time.Duration, error) {
+	if !strings.Contains(value, ".") {
+		val, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		if val > math.MaxInt64/int64(unit) {
+			return 0, errors.New("duration exceeds maximum size of a 64-bit integer")
+		}
+		return time.Duration(val) * unit, nil
+	}
+	val, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if val*float64(unit) >= math.MaxInt64 {
+		return 0, errors.New("duration exceeds maximum size of a 64-bit integer")
+	}
+	return time.Duration(val * float64(unit)), nil
+}
+
+// formatDurationUnit formats the given number of units.
+func formatDurationUnit(count int64, name, compact string, useCompact bool) string {
+	if useCompact {
+		return strconv.FormatInt(count, 10) + compact
+	}
+	if count != 1 {
+		name += "s"
+	}
+	return strconv.FormatInt(count, 10) + " " + name
+}
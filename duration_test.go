@@ -2,6 +2,7 @@ package types_test
 
 import (
 	"testing"
+	"time"
 
 	"go.innotegrity.dev/types"
 )
@@ -29,3 +30,102 @@ func TestDuration1(t *testing.T) {
 	}
 
 }
+
+func TestDuration2(t *testing.T) {
+	durations := map[string]string{
+		"P1Y2M3DT4H5M":  "P428DT4H5M",
+		"PT15M":         "PT15M",
+		"P2W":           "P14D",
+		"-PT1.5S":       "-PT1.5S",
+		"PT36H":         "P1DT12H",
+		"P0D":           "PT0S",
+		"PT0.000001S":   "PT0.000001S",
+		"P1DT2H30M0.5S": "P1DT2H30M0.5S",
+	}
+	for str, expected := range durations {
+		dur, err := types.ParseDuration(str)
+		if err != nil {
+			t.Errorf("failed to parse duration: %v", err)
+		} else if dur.ISO8601String() != expected {
+			t.Errorf("expected '%s' to be formatted as '%s' but got '%s'", str, expected, dur.ISO8601String())
+		}
+	}
+	for _, str := range []string{"P", "PT", "P1H", "PT1D", "P1DT", "P1.5"} {
+		if _, err := types.ParseISO8601Duration(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
+
+func TestDuration3(t *testing.T) {
+	durations := []struct {
+		dur      time.Duration
+		opts     types.DurationFormatOptions
+		expected string
+	}{
+		{51 * time.Hour, types.DurationFormatOptions{}, "2 days 3 hours"},
+		{51 * time.Hour, types.DurationFormatOptions{Compact: true}, "2d3h"},
+		{45 * 24 * time.Hour, types.DurationFormatOptions{}, "1 month 2 weeks 1 day"},
+		{90*time.Minute + 40*time.Second, types.DurationFormatOptions{MaxUnits: 2}, "1 hour 31 minutes"},
+		{1500 * time.Millisecond, types.DurationFormatOptions{Resolution: types.Duration(time.Second)}, "2 seconds"},
+		{-time.Minute, types.DurationFormatOptions{Compact: true}, "-1m"},
+		{0, types.DurationFormatOptions{}, "0 seconds"},
+	}
+	for _, d := range durations {
+		if str := types.Duration(d.dur).Humanize(d.opts); str != d.expected {
+			t.Errorf("expected %s to be formatted as '%s' but got '%s'", d.dur, d.expected, str)
+		}
+	}
+}
+
+func TestDuration4(t *testing.T) {
+	for _, str := range []string{"30d", "1y", "1h30m0s", "-2d"} {
+		dur, err := types.ParseDuration(str)
+		if err != nil {
+			t.Fatalf("failed to parse duration: %v", err)
+		}
+		text, err := dur.MarshalText()
+		if err != nil {
+			t.Fatalf("failed to marshal duration: %v", err)
+		}
+		if string(text) != str {
+			t.Errorf("expected '%s' to be marshaled unchanged but got '%s'", str, text)
+		}
+	}
+}
+
+func TestDuration5(t *testing.T) {
+	dur := 9*types.Day + types.Duration(13*time.Hour)
+	if dur.Round(types.Day) != 10*types.Day || dur.Truncate(types.Day) != 9*types.Day {
+		t.Errorf("unexpected day rounding for %s: %s, %s", dur, dur.Round(types.Day), dur.Truncate(types.Day))
+	}
+	if dur.Round(types.Week) != types.Week || (-dur).Truncate(types.Week) != -types.Week {
+		t.Errorf("unexpected week rounding for %s: %s, %s", dur, dur.Round(types.Week), (-dur).Truncate(types.Week))
+	}
+}
+
+func TestDuration6(t *testing.T) {
+	durations := map[string]time.Duration{
+		"1d12h":        36 * time.Hour,
+		"2w3d":         17 * 24 * time.Hour,
+		"-1d30m":       -(24*time.Hour + 30*time.Minute),
+		"1y1mo1w1d1h":  (365+30+7+1)*24*time.Hour + time.Hour,
+		"1.5d":         36 * time.Hour,
+		"1h30m15s":     time.Hour + 30*time.Minute + 15*time.Second,
+		"0":            0,
+		"2d500ms100us": 48*time.Hour + 500*time.Millisecond + 100*time.Microsecond,
+	}
+	for str, expected := range durations {
+		dur, err := types.ParseDuration(str)
+		if err != nil {
+			t.Errorf("failed to parse duration: %v", err)
+		} else if time.Duration(dur) != expected {
+			t.Errorf("expected '%s' to be %s but got %s", str, expected, time.Duration(dur))
+		}
+	}
+	for _, str := range []string{"1x", "d", "1d2", "300000y"} {
+		if _, err := types.ParseDuration(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Base64Bytes is a byte slice which is marshaled as a base64-encoded string.
+//
+// It is marshaled using standard, padded base64 encoding but can be unmarshaled from either standard or URL-safe
+// encoding, with or without padding.
+type Base64Bytes []byte
+
+// MarshalJSON marshals the [Base64Bytes] object to JSON.
+func (b Base64Bytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.String())
+}
+
+// MarshalText marshals the [Base64Bytes] object to plain text.
+func (b Base64Bytes) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// String returns the [Base64Bytes] object as a standard, padded base64-encoded string.
+func (b Base64Bytes) String() string {
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// UnmarshalJSON parses the JSON data into a [Base64Bytes] object.
+func (b *Base64Bytes) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [Base64Bytes] object.
+func (b *Base64Bytes) UnmarshalText(data []byte) error {
+	str := strings.TrimRight(strings.TrimSpace(string(data)), "=")
+	enc := base64.RawStdEncoding
+	if strings.ContainsAny(str, "-_") {
+		enc = base64.RawURLEncoding
+	}
+	decoded, err := enc.DecodeString(str)
+	if err != nil {
+		return fmt.Errorf("failed to decode base64 data: %w", err)
+	}
+	*b = decoded
+	return nil
+}
+
+// HexBytes is a byte slice which is marshaled as a hex-encoded string.
+//
+// It is marshaled as lowercase hex but can be unmarshaled from either case, with or without a leading "0x".
+type HexBytes []byte
+
+// MarshalJSON marshals the [HexBytes] object to JSON.
+func (h HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.String())
+}
+
+// MarshalText marshals the [HexBytes] object to plain text.
+func (h HexBytes) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// String returns the [HexBytes] object as a lowercase hex-encoded string.
+func (h HexBytes) String() string {
+	return hex.EncodeToString(h)
+}
+
+// UnmarshalJSON parses the JSON data into a [HexBytes] object.
+func (h *HexBytes) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return h.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [HexBytes] object.
+func (h *HexBytes) UnmarshalText(data []byte) error {
+	str := strings.TrimSpace(string(data))
+	if len(str) >= 2 && (str[:2] == "0x" || str[:2] == "0X") {
+		str = str[2:]
+	}
+	decoded, err := hex.DecodeString(str)
+	if err != nil {
+		return fmt.Errorf("failed to decode hex data: %w", err)
+	}
+	*h = decoded
+	return nil
+}
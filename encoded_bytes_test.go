@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestEncodedBytes1(t *testing.T) {
+	var config struct {
+		Key   types.HexBytes    `json:"key"`
+		Salt  types.Base64Bytes `json:"salt"`
+		Token types.Base64Bytes `json:"token"`
+	}
+	data := `{"key": "0xDEADbeef", "salt": "c2FsdHk=", "token": "-_8"}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("failed to unmarshal encoded bytes: %v", err)
+	}
+	if !bytes.Equal(config.Key, []byte{0xde, 0xad, 0xbe, 0xef}) || string(config.Salt) != "salty" ||
+		!bytes.Equal(config.Token, []byte{0xfb, 0xff}) {
+		t.Errorf("unexpected decoded bytes: %+v", config)
+	}
+	out, _ := json.Marshal(config)
+	if string(out) != `{"key":"deadbeef","salt":"c2FsdHk=","token":"+/8="}` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"key": "xyz"}`), &config); err == nil {
+		t.Errorf("expected invalid hex to fail")
+	}
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ExpandEnv replaces ${VAR} and $VAR references in the given string with the values of the matching environment
+// variables.
+//
+// A reference in the form ${VAR:-default} is replaced with default if the variable is unset or empty, and "$$" is
+// replaced with a literal "$". If strict is true, an error naming every undefined variable without a default is
+// returned; otherwise undefined variables are replaced with an empty string.
+func ExpandEnv(str string, strict bool) (string, error) {
+	var undefined []string
+	expanded := os.Expand(str, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		name, def, hasDefault := strings.Cut(name, ":-")
+		value, found := os.LookupEnv(name)
+		switch {
+		case hasDefault && value == "":
+			return def
+		case !found && strict:
+			undefined = append(undefined, name)
+		}
+		return value
+	})
+	if len(undefined) > 0 {
+		return "", fmt.Errorf("failed to expand '%s': undefined environment variables: %s", str,
+			strings.Join(undefined, ", "))
+	}
+	return expanded, nil
+}
+
+// EnvString is a string which has environment variable references expanded when it is unmarshaled.
+//
+// References are expanded by [ExpandEnv] and undefined variables are replaced with an empty string. Use
+// [StrictEnvString] to reject undefined variables instead.
+type EnvString string
+
+// String returns the [EnvString] object as a string.
+func (e EnvString) String() string {
+	return string(e)
+}
+
+// UnmarshalJSON parses the JSON string and expands any environment variable references in it.
+func (e *EnvString) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText expands any environment variable references in the text.
+func (e *EnvString) UnmarshalText(data []byte) error {
+	str, err := ExpandEnv(string(data), false)
+	if err != nil {
+		return err
+	}
+	*e = EnvString(str)
+	return nil
+}
+
+// StrictEnvString is an [EnvString] which fails to unmarshal if it references an undefined environment variable
+// without a default.
+type StrictEnvString string
+
+// String returns the [StrictEnvString] object as a string.
+func (e StrictEnvString) String() string {
+	return string(e)
+}
+
+// UnmarshalJSON parses the JSON string and expands any environment variable references in it.
+func (e *StrictEnvString) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText expands any environment variable references in the text.
+func (e *StrictEnvString) UnmarshalText(data []byte) error {
+	str, err := ExpandEnv(string(data), true)
+	if err != nil {
+		return err
+	}
+	*e = StrictEnvString(str)
+	return nil
+}
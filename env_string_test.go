@@ -0,0 +1,37 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestEnvString1(t *testing.T) {
+	t.Setenv("APP_HOST", "db.internal")
+	t.Setenv("APP_PORT", "5432")
+	t.Setenv("APP_EMPTY", "")
+
+	var config struct {
+		URL     types.EnvString       `json:"url"`
+		Mode    types.EnvString       `json:"mode"`
+		Price   types.EnvString       `json:"price"`
+		Missing types.EnvString       `json:"missing"`
+		Secret  types.StrictEnvString `json:"secret"`
+	}
+	data := `{"url": "postgres://${APP_HOST}:$APP_PORT/app", "mode": "${APP_EMPTY:-production}", "price": "$$5",
+		"missing": "[${APP_UNDEFINED}]", "secret": "${APP_HOST}"}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("failed to unmarshal environment strings: %v", err)
+	}
+	if config.URL != "postgres://db.internal:5432/app" || config.Mode != "production" || config.Price != "$5" ||
+		config.Missing != "[]" || config.Secret != "db.internal" {
+		t.Errorf("unexpected expanded values: %+v", config)
+	}
+
+	err := json.Unmarshal([]byte(`{"secret": "${APP_UNDEFINED}-$APP_ALSO_UNDEFINED"}`), &config)
+	if err == nil {
+		t.Errorf("expected strict expansion of undefined variables to fail")
+	}
+	t.Logf("strict error: %v", err)
+}
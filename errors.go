@@ -18,4 +18,64 @@ const (
 
 	// PathWriteError indicates there was an error while writing to the file.
 	PathWriteError = 6
+
+	// PathCopyError indicates there was an error while copying the file.
+	PathCopyError = 7
+
+	// PathMoveError indicates there was an error while moving the path.
+	PathMoveError = 8
+
+	// PathMovePartialError indicates the file was copied to its destination but could not be removed from its
+	// original location.
+	PathMovePartialError = 9
+
+	// PathDeleteError indicates there was an error while removing the path.
+	PathDeleteError = 10
+
+	// PathSymlinkError indicates there was an error while creating, reading or resolving a symbolic link.
+	PathSymlinkError = 11
+
+	// PathChecksumError indicates there was an error while computing the checksum of the file.
+	PathChecksumError = 12
+
+	// PathChecksumMismatchError indicates the checksum of the file did not match the expected value.
+	PathChecksumMismatchError = 13
+
+	// PathDiskUsageError indicates there was an error while determining disk usage or free space.
+	PathDiskUsageError = 14
+
+	// PathReadError indicates there was an error while reading from the file.
+	PathReadError = 15
+
+	// PathFileTooLargeError indicates the file is larger than the maximum size allowed.
+	PathFileTooLargeError = 16
+
+	// PathTraversalError indicates the path would escape the folder it is required to be within.
+	PathTraversalError = 17
+
+	// PathLinkError indicates there was an error while creating a hard link.
+	PathLinkError = 18
+
+	// PathExistsError indicates the path already exists when it was required not to.
+	PathExistsError = 19
+
+	// PathChownSkippedError indicates the ownership of the path was not changed because the current user is not
+	// permitted to change it.
+	PathChownSkippedError = 20
+
+	// PathTimesError indicates there was an error while changing the access and modification times of the path.
+	PathTimesError = 21
+
+	// PathAccessDeniedError indicates the path may not be accessed in the requested way.
+	PathAccessDeniedError = 22
+
+	// PathTruncateError indicates there was an error while changing the size of the file.
+	PathTruncateError = 23
+
+	// PathTypeError indicates the path exists but is not the expected type, such as a file rather than a folder.
+	PathTypeError = 24
+
+	// PathYAMLNotRegisteredError indicates a YAML file could not be read or written because no YAML library has been
+	// registered with [RegisterYAML].
+	PathYAMLNotRegisteredError = 25
 )
@@ -0,0 +1,196 @@
+package types
+
+import (
+	"sync"
+	"time"
+)
+
+// ExpiringMapOptions holds the options for creating an [ExpiringMap] object.
+type ExpiringMapOptions struct {
+	// TTL is the default amount of time after which an entry expires. If 0 or less, entries do not expire unless
+	// they are stored with their own TTL.
+	TTL Duration `json:"ttl" yaml:"ttl" mapstructure:"ttl"`
+
+	// CleanupInterval is how often the background janitor removes expired entries. If 0 or less, no janitor is
+	// started and expired entries are only removed when they are accessed or when Purge is called.
+	CleanupInterval Duration `json:"cleanup_interval" yaml:"cleanup_interval" mapstructure:"cleanup_interval"`
+
+	// Clock provides the current time when checking for expired entries. If nil, [SystemClock] is used.
+	Clock Clock `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+// ExpiringMap is a generic map whose entries are removed once their time to live elapses.
+//
+// It is intended for short-lived state such as deduplication windows or sessions. ExpiringMap objects are safe for
+// concurrent use. If a cleanup interval is configured, Close must be called to stop the background janitor once the
+// map is no longer needed.
+type ExpiringMap[K comparable, V any] struct {
+	done      chan struct{}
+	entries   map[K]*expiringMapEntry[V]
+	mu        sync.Mutex
+	opts      ExpiringMapOptions
+	closeOnce sync.Once
+}
+
+// expiringMapEntry is a single entry stored in an [ExpiringMap] object.
+type expiringMapEntry[V any] struct {
+	expires time.Time
+	ttl     Duration
+	value   V
+}
+
+// NewExpiringMap creates a new, empty ExpiringMap object with the given options.
+func NewExpiringMap[K comparable, V any](opts ExpiringMapOptions) *ExpiringMap[K, V] {
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+	m := &ExpiringMap[K, V]{
+		done:    make(chan struct{}),
+		entries: map[K]*expiringMapEntry[V]{},
+		opts:    opts,
+	}
+	if opts.CleanupInterval > 0 {
+		go m.janitor(time.Duration(opts.CleanupInterval))
+	}
+	return m
+}
+
+// Close stops the background janitor, if any. The map may still be used after it is closed.
+func (m *ExpiringMap[K, V]) Close() {
+	m.closeOnce.Do(func() {
+		close(m.done)
+	})
+}
+
+// Delete removes the given key from the map and returns whether or not it was present and unexpired.
+func (m *ExpiringMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[key]
+	delete(m.entries, key)
+	return exists && !m.expired(entry)
+}
+
+// Extend pushes back the expiration time of the given key by the given amount of time.
+//
+// It returns false if the key is not present or has already expired. Entries which never expire are unchanged.
+func (m *ExpiringMap[K, V]) Extend(key K, d Duration) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return false
+	}
+	if !entry.expires.IsZero() {
+		entry.expires = entry.expires.Add(time.Duration(d))
+	}
+	return true
+}
+
+// Get returns the value stored for the given key and whether or not it was found.
+//
+// Expired entries are removed and reported as not found.
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry := m.lookup(key); entry != nil {
+		return entry.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Len returns the number of entries in the map, including any expired entries which have not yet been removed.
+func (m *ExpiringMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// Purge removes all expired entries from the map and returns the number removed.
+func (m *ExpiringMap[K, V]) Purge() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	for key, entry := range m.entries {
+		if m.expired(entry) {
+			delete(m.entries, key)
+			n++
+		}
+	}
+	return n
+}
+
+// Set stores the value for the given key using the default TTL from the map options.
+func (m *ExpiringMap[K, V]) Set(key K, value V) {
+	m.SetWithTTL(key, value, m.opts.TTL)
+}
+
+// SetWithTTL stores the value for the given key, expiring it after the given amount of time.
+//
+// A ttl of 0 or less means the entry does not expire.
+func (m *ExpiringMap[K, V]) SetWithTTL(key K, value V, ttl Duration) {
+	entry := &expiringMapEntry[V]{ttl: ttl, value: value}
+	if ttl > 0 {
+		entry.expires = m.opts.Clock.Now().Add(time.Duration(ttl))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = entry
+}
+
+// Touch resets the expiration time of the given key to its full TTL from the current time.
+//
+// It returns false if the key is not present or has already expired.
+func (m *ExpiringMap[K, V]) Touch(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.lookup(key)
+	if entry == nil {
+		return false
+	}
+	if entry.ttl > 0 {
+		entry.expires = m.opts.Clock.Now().Add(time.Duration(entry.ttl))
+	}
+	return true
+}
+
+// expired returns whether or not the given entry has passed its expiration time.
+func (m *ExpiringMap[K, V]) expired(entry *expiringMapEntry[V]) bool {
+	return !entry.expires.IsZero() && !m.opts.Clock.Now().Before(entry.expires)
+}
+
+// janitor periodically removes expired entries until the map is closed.
+func (m *ExpiringMap[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.Purge()
+		case <-m.done:
+			return
+		}
+	}
+}
+
+// lookup returns the unexpired entry for the given key, removing it if it has expired.
+//
+// The map must be locked when calling this function.
+func (m *ExpiringMap[K, V]) lookup(key K) *expiringMapEntry[V] {
+	entry, exists := m.entries[key]
+	if !exists {
+		return nil
+	}
+	if m.expired(entry) {
+		delete(m.entries, key)
+		return nil
+	}
+	return entry
+}
@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestExpiringMap1(t *testing.T) {
+	clock := &manualClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	sessions := types.NewExpiringMap[string, string](types.ExpiringMapOptions{
+		TTL:   types.Duration(time.Minute),
+		Clock: clock,
+	})
+	defer sessions.Close()
+
+	sessions.Set("alice", "token-a")
+	sessions.Set("bob", "token-b")
+	sessions.SetWithTTL("service", "token-s", 0)
+
+	clock.now = clock.now.Add(45 * time.Second)
+	sessions.Touch("alice")
+	sessions.Extend("bob", types.Duration(10*time.Second))
+
+	clock.now = clock.now.Add(30 * time.Second)
+	if _, ok := sessions.Get("alice"); !ok {
+		t.Errorf("expected touched session to still be present")
+	}
+	if _, ok := sessions.Get("bob"); ok {
+		t.Errorf("expected extended session to have expired")
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	if n := sessions.Purge(); n != 1 || sessions.Len() != 1 {
+		t.Errorf("expected only the session without a TTL to remain but purged %d", n)
+	}
+}
+
+func TestExpiringMap2(t *testing.T) {
+	seen := types.NewExpiringMap[int, bool](types.ExpiringMapOptions{
+		TTL:             types.Duration(time.Millisecond),
+		CleanupInterval: types.Duration(time.Millisecond),
+	})
+	defer seen.Close()
+	seen.Set(1, true)
+	deadline := time.Now().Add(5 * time.Second)
+	for seen.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if seen.Len() != 0 {
+		t.Errorf("expected janitor to remove expired entry")
+	}
+}
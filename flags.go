@@ -0,0 +1,204 @@
+package types
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// FlagBits is the set of unsigned integer types which can be used as the bits of a [Flags] object.
+type FlagBits interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// flagName associates a registered name with its bits.
+type flagName struct {
+	bits uint64
+	name string
+}
+
+// flagNamesRegistry maps each registered flag type to its names, ordered by value.
+var flagNamesRegistry sync.Map
+
+// RegisterFlagNames registers the names used when marshaling and unmarshaling [Flags] objects with bits of type T.
+//
+// It is typically called from an init function alongside the flag constants:
+//
+//	type Permission uint32
+//
+//	const (
+//		PermRead Permission = 1 << iota
+//		PermWrite
+//		PermAdmin
+//	)
+//
+//	func init() {
+//		types.RegisterFlagNames(map[string]Permission{"read": PermRead, "write": PermWrite, "admin": PermAdmin})
+//	}
+//
+// Registering names for the same type again replaces the previous names.
+func RegisterFlagNames[T FlagBits](names map[string]T) {
+	registered := make([]flagName, 0, len(names))
+	for name, bits := range names {
+		registered = append(registered, flagName{bits: uint64(bits), name: strings.ToLower(name)})
+	}
+	slices.SortFunc(registered, func(a, b flagName) int {
+		if c := cmp.Compare(a.bits, b.bits); c != 0 {
+			return c
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	flagNamesRegistry.Store(reflect.TypeFor[T](), registered)
+}
+
+// Flags is a bitmask of flags of type T which is marshaled as a list of flag names.
+//
+// Names must be registered for T with [RegisterFlagNames]. When unmarshaling, the flags may be given as a JSON list
+// of names, a comma-separated string of names such as "read, write, admin" or an integer bitmask. Any bits which do
+// not correspond to a registered name are marshaled as a hexadecimal value such as "0x10", which is also accepted
+// in place of a name when unmarshaling.
+type Flags[T FlagBits] struct {
+	bits T
+}
+
+// NewFlags creates a new Flags object with the given flags set.
+func NewFlags[T FlagBits](flags ...T) Flags[T] {
+	return Flags[T]{}.Set(flags...)
+}
+
+// ParseFlags parses the given comma-separated list of flag names into a [Flags] object.
+func ParseFlags[T FlagBits](flags string) (Flags[T], error) {
+	var f Flags[T]
+	for _, name := range strings.Split(flags, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		bits, err := lookupFlagName[T](name)
+		if err != nil {
+			return Flags[T]{}, err
+		}
+		f.bits |= bits
+	}
+	return f, nil
+}
+
+// Bits returns the combined bitmask of the flags which are set.
+func (f Flags[T]) Bits() T {
+	return f.bits
+}
+
+// Clear returns a copy of the object with the given flags unset.
+func (f Flags[T]) Clear(flags ...T) Flags[T] {
+	for _, flag := range flags {
+		f.bits &^= flag
+	}
+	return f
+}
+
+// Has returns whether or not all of the given flags are set.
+func (f Flags[T]) Has(flags ...T) bool {
+	for _, flag := range flags {
+		if f.bits&flag != flag {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON marshals the [Flags] object to a JSON list of flag names.
+func (f Flags[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Names())
+}
+
+// Names returns the registered names of the flags which are set, ordered by value.
+//
+// Any remaining bits with no registered name are included as a single hexadecimal value such as "0x10".
+func (f Flags[T]) Names() []string {
+	names := []string{}
+	remaining := uint64(f.bits)
+	for _, n := range registeredFlagNames[T]() {
+		if n.bits != 0 && uint64(f.bits)&n.bits == n.bits {
+			names = append(names, n.name)
+			remaining &^= n.bits
+		}
+	}
+	if remaining != 0 {
+		names = append(names, fmt.Sprintf("%#x", remaining))
+	}
+	return names
+}
+
+// Set returns a copy of the object with the given flags set.
+func (f Flags[T]) Set(flags ...T) Flags[T] {
+	for _, flag := range flags {
+		f.bits |= flag
+	}
+	return f
+}
+
+// String returns the [Flags] object as a comma-separated list of flag names, such as "read, write".
+func (f Flags[T]) String() string {
+	return strings.Join(f.Names(), ", ")
+}
+
+// UnmarshalJSON parses the JSON data into a [Flags] object.
+//
+// The data may be a list of names, a comma-separated string of names or an integer bitmask.
+func (f *Flags[T]) UnmarshalJSON(data []byte) error {
+	var bits uint64
+	if err := json.Unmarshal(data, &bits); err == nil {
+		*f = Flags[T]{bits: T(bits)}
+		return nil
+	}
+
+	var names []string
+	if err := json.Unmarshal(data, &names); err == nil {
+		flags, err := ParseFlags[T](strings.Join(names, ","))
+		if err != nil {
+			return err
+		}
+		*f = flags
+		return nil
+	}
+
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return f.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the comma-separated list of flag names into a [Flags] object.
+func (f *Flags[T]) UnmarshalText(data []byte) error {
+	flags, err := ParseFlags[T](string(data))
+	if err != nil {
+		return err
+	}
+	*f = flags
+	return nil
+}
+
+// lookupFlagName returns the bits for the given flag name, which may also be a numeric value.
+func lookupFlagName[T FlagBits](name string) (T, error) {
+	for _, n := range registeredFlagNames[T]() {
+		if strings.EqualFold(n.name, name) {
+			return T(n.bits), nil
+		}
+	}
+	if bits, err := strconv.ParseUint(name, 0, 64); err == nil {
+		return T(bits), nil
+	}
+	return 0, fmt.Errorf("unknown flag '%s' for %s", name, reflect.TypeFor[T]())
+}
+
+// registeredFlagNames returns the names registered for T or nil if none have been registered.
+func registeredFlagNames[T FlagBits]() []flagName {
+	names, _ := flagNamesRegistry.Load(reflect.TypeFor[T]())
+	registered, _ := names.([]flagName)
+	return registered
+}
@@ -0,0 +1,47 @@
+package types_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+type permission uint32
+
+const (
+	permRead permission = 1 << iota
+	permWrite
+	permAdmin
+)
+
+func init() {
+	types.RegisterFlagNames(map[string]permission{"read": permRead, "write": permWrite, "admin": permAdmin})
+}
+
+func TestFlags1(t *testing.T) {
+	var role struct {
+		Viewer types.Flags[permission] `json:"viewer"`
+		Editor types.Flags[permission] `json:"editor"`
+		Owner  types.Flags[permission] `json:"owner"`
+	}
+	data := `{"viewer": "read", "editor": ["read", "WRITE"], "owner": 7}`
+	if err := json.Unmarshal([]byte(data), &role); err != nil {
+		t.Fatalf("failed to unmarshal flags: %v", err)
+	}
+	if !role.Editor.Has(permRead, permWrite) || role.Editor.Has(permAdmin) || role.Owner.String() != "read, write, admin" {
+		t.Errorf("unexpected flags: %+v", role)
+	}
+
+	custom := types.NewFlags(permRead, permission(16)).Clear(permRead)
+	if names := custom.Names(); !slices.Equal(names, []string{"0x10"}) {
+		t.Errorf("unexpected names for unregistered bits: %v", names)
+	}
+	out, _ := json.Marshal(role)
+	t.Logf("roles: %s", out)
+
+	if _, err := types.ParseFlags[permission]("read, delete"); err == nil {
+		t.Errorf("expected unknown flag to fail")
+	}
+}
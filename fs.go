@@ -0,0 +1,522 @@
+package types
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FS describes a filesystem on which [Path] operations are performed.
+//
+// [OSFS] is used whenever a [Path] does not have a filesystem configured. Use [ReadOnlyFS] to perform read
+// operations against an [fs.FS] such as an [embed.FS] or a [testing/fstest.MapFS], or supply your own
+// implementation, such as an in-memory filesystem for unit tests.
+type FS interface {
+	// Chmod changes the mode of the named file.
+	Chmod(name string, mode os.FileMode) error
+
+	// Chown changes the numeric uid and gid of the named file, following symbolic links.
+	Chown(name string, uid, gid int) error
+
+	// Chtimes changes the access and modification times of the named file.
+	Chtimes(name string, atime, mtime time.Time) error
+
+	// Lchown changes the numeric uid and gid of the named file without following symbolic links.
+	Lchown(name string, uid, gid int) error
+
+	// Link creates newname as a hard link to the oldname file.
+	Link(oldname, newname string) error
+
+	// Lstat returns information about the named file without following symbolic links.
+	Lstat(name string) (fs.FileInfo, error)
+
+	// Mkdir creates a new directory with the specified name and permission bits.
+	Mkdir(name string, perm os.FileMode) error
+
+	// MkdirAll creates a directory named path, along with any necessary parents.
+	MkdirAll(path string, perm os.FileMode) error
+
+	// Open opens the named file for reading.
+	Open(name string) (File, error)
+
+	// OpenFile opens the named file with the specified flags and permissions.
+	OpenFile(name string, flag int, perm os.FileMode) (File, error)
+
+	// ReadDir reads the named directory, returning all of its entries sorted by filename.
+	ReadDir(name string) ([]fs.DirEntry, error)
+
+	// Readlink returns the destination of the named symbolic link.
+	Readlink(name string) (string, error)
+
+	// Remove removes the named file or empty directory.
+	Remove(name string) error
+
+	// RemoveAll removes path and any children it contains.
+	RemoveAll(path string) error
+
+	// Rename renames (moves) oldpath to newpath.
+	Rename(oldpath, newpath string) error
+
+	// Stat returns information about the named file, following symbolic links.
+	Stat(name string) (fs.FileInfo, error)
+
+	// Symlink creates newname as a symbolic link to oldname.
+	Symlink(oldname, newname string) error
+
+	// Truncate changes the size of the named file.
+	Truncate(name string, size int64) error
+}
+
+// File describes an open file returned by an [FS].
+//
+// [*os.File] satisfies this interface.
+type File interface {
+	io.Reader
+	io.Writer
+	io.Seeker
+	io.Closer
+
+	// Name returns the name of the file as presented to [FS.Open] or [FS.OpenFile].
+	Name() string
+
+	// Stat returns information about the file.
+	Stat() (fs.FileInfo, error)
+
+	// Sync commits the current contents of the file to stable storage.
+	Sync() error
+}
+
+// OSFS is an [FS] which performs all operations on the operating system's filesystem using the [os] package.
+type OSFS struct{}
+
+// Chmod changes the mode of the named file.
+func (OSFS) Chmod(name string, mode os.FileMode) error {
+	return os.Chmod(name, mode)
+}
+
+// Chown changes the numeric uid and gid of the named file, following symbolic links.
+func (OSFS) Chown(name string, uid, gid int) error {
+	return os.Chown(name, uid, gid)
+}
+
+// Chtimes changes the access and modification times of the named file.
+func (OSFS) Chtimes(name string, atime, mtime time.Time) error {
+	return os.Chtimes(name, atime, mtime)
+}
+
+// Lchown changes the numeric uid and gid of the named file without following symbolic links.
+func (OSFS) Lchown(name string, uid, gid int) error {
+	return os.Lchown(name, uid, gid)
+}
+
+// Link creates newname as a hard link to the oldname file.
+func (OSFS) Link(oldname, newname string) error {
+	return os.Link(oldname, newname)
+}
+
+// Lstat returns information about the named file without following symbolic links.
+func (OSFS) Lstat(name string) (fs.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+// Mkdir creates a new directory with the specified name and permission bits.
+func (OSFS) Mkdir(name string, perm os.FileMode) error {
+	return os.Mkdir(name, perm)
+}
+
+// MkdirAll creates a directory named path, along with any necessary parents.
+func (OSFS) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+// Open opens the named file for reading.
+func (OSFS) Open(name string) (File, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// OpenFile opens the named file with the specified flags and permissions.
+func (OSFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	f, err := os.OpenFile(name, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ReadDir reads the named directory, returning all of its entries sorted by filename.
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+// Readlink returns the destination of the named symbolic link.
+func (OSFS) Readlink(name string) (string, error) {
+	return os.Readlink(name)
+}
+
+// Remove removes the named file or empty directory.
+func (OSFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+// RemoveAll removes path and any children it contains.
+func (OSFS) RemoveAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+// Rename renames (moves) oldpath to newpath.
+func (OSFS) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
+
+// Stat returns information about the named file, following symbolic links.
+func (OSFS) Stat(name string) (fs.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// Symlink creates newname as a symbolic link to oldname.
+func (OSFS) Symlink(oldname, newname string) error {
+	return os.Symlink(oldname, newname)
+}
+
+// Truncate changes the size of the named file.
+func (OSFS) Truncate(name string, size int64) error {
+	return os.Truncate(name, size)
+}
+
+// ReadOnlyFS returns an [FS] which reads from the given [fs.FS].
+//
+// Paths are converted to the slash-separated, unrooted form required by [fs.FS], so "/etc/app/config.json" and
+// "etc/app/config.json" both refer to the same file. Any operation which would modify the filesystem fails with an
+// error wrapping [fs.ErrPermission]. Since [fs.FS] has no notion of symbolic links, Lstat behaves like Stat.
+func ReadOnlyFS(fsys fs.FS) FS {
+	return &readOnlyFS{fsys: fsys}
+}
+
+// readOnlyFS is an [FS] which wraps an [fs.FS].
+type readOnlyFS struct {
+	fsys fs.FS
+}
+
+// Chmod always fails since the filesystem is read-only.
+func (r *readOnlyFS) Chmod(name string, _ os.FileMode) error {
+	return readOnlyError("chmod", name)
+}
+
+// Chown always fails since the filesystem is read-only.
+func (r *readOnlyFS) Chown(name string, _, _ int) error {
+	return readOnlyError("chown", name)
+}
+
+// Chtimes always fails since the filesystem is read-only.
+func (r *readOnlyFS) Chtimes(name string, _, _ time.Time) error {
+	return readOnlyError("chtimes", name)
+}
+
+// Lchown always fails since the filesystem is read-only.
+func (r *readOnlyFS) Lchown(name string, _, _ int) error {
+	return readOnlyError("lchown", name)
+}
+
+// Link always fails since the filesystem is read-only.
+func (r *readOnlyFS) Link(oldname, newname string) error {
+	return &os.LinkError{Op: "link", Old: oldname, New: newname, Err: fs.ErrPermission}
+}
+
+// Lstat returns information about the named file.
+func (r *readOnlyFS) Lstat(name string) (fs.FileInfo, error) {
+	return r.Stat(name)
+}
+
+// Mkdir always fails since the filesystem is read-only.
+func (r *readOnlyFS) Mkdir(name string, _ os.FileMode) error {
+	return readOnlyError("mkdir", name)
+}
+
+// MkdirAll always fails since the filesystem is read-only.
+func (r *readOnlyFS) MkdirAll(path string, _ os.FileMode) error {
+	return readOnlyError("mkdir", path)
+}
+
+// Open opens the named file for reading.
+func (r *readOnlyFS) Open(name string) (File, error) {
+	f, err := r.fsys.Open(toFSName(name))
+	if err != nil {
+		return nil, err
+	}
+	return &readOnlyFile{File: f, name: name}, nil
+}
+
+// OpenFile opens the named file for reading.
+//
+// Any flags which would create or modify the file cause the call to fail.
+func (r *readOnlyFS) OpenFile(name string, flag int, _ os.FileMode) (File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		return nil, readOnlyError("open", name)
+	}
+	return r.Open(name)
+}
+
+// ReadDir reads the named directory, returning all of its entries sorted by filename.
+func (r *readOnlyFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(r.fsys, toFSName(name))
+}
+
+// Readlink always fails since [fs.FS] does not support symbolic links.
+func (r *readOnlyFS) Readlink(name string) (string, error) {
+	return "", &fs.PathError{Op: "readlink", Path: name, Err: fs.ErrInvalid}
+}
+
+// Remove always fails since the filesystem is read-only.
+func (r *readOnlyFS) Remove(name string) error {
+	return readOnlyError("remove", name)
+}
+
+// RemoveAll always fails since the filesystem is read-only.
+func (r *readOnlyFS) RemoveAll(path string) error {
+	return readOnlyError("remove", path)
+}
+
+// Rename always fails since the filesystem is read-only.
+func (r *readOnlyFS) Rename(oldpath, newpath string) error {
+	return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: fs.ErrPermission}
+}
+
+// Stat returns information about the named file.
+func (r *readOnlyFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(r.fsys, toFSName(name))
+}
+
+// Symlink always fails since the filesystem is read-only.
+func (r *readOnlyFS) Symlink(_, newname string) error {
+	return readOnlyError("symlink", newname)
+}
+
+// Truncate always fails since the filesystem is read-only.
+func (r *readOnlyFS) Truncate(name string, _ int64) error {
+	return readOnlyError("truncate", name)
+}
+
+// readOnlyFile is a [File] which wraps an [fs.File].
+type readOnlyFile struct {
+	fs.File
+	name string
+}
+
+// Name returns the name of the file as presented to Open.
+func (f *readOnlyFile) Name() string {
+	return f.name
+}
+
+// Seek sets the offset for the next Read on the file, if the underlying file supports seeking.
+func (f *readOnlyFile) Seek(offset int64, whence int) (int64, error) {
+	if s, ok := f.File.(io.Seeker); ok {
+		return s.Seek(offset, whence)
+	}
+	return 0, &fs.PathError{Op: "seek", Path: f.name, Err: errors.ErrUnsupported}
+}
+
+// Sync does nothing since the file cannot be modified.
+func (f *readOnlyFile) Sync() error {
+	return nil
+}
+
+// Write always fails since the file is read-only.
+func (f *readOnlyFile) Write(_ []byte) (int, error) {
+	return 0, readOnlyError("write", f.name)
+}
+
+// createTemp creates a new temporary file in the given directory of the filesystem with the given permissions (before
+// the umask), similar to [os.CreateTemp].
+func createTemp(fsys FS, dir, pattern string, perm os.FileMode) (File, error) {
+	prefix, suffix := splitTempPattern(pattern)
+	for try := 0; ; try++ {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+suffix)
+		f, err := fsys.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, fs.ErrExist) && try < 10000 {
+			continue
+		}
+		return f, err
+	}
+}
+
+// globDirFS appends the names of the entries in the given directory matching the pattern to matches.
+func globDirFS(fsys FS, dir, pattern string, matches []string) ([]string, error) {
+	entries, err := fsys.ReadDir(dir)
+	if err != nil {
+		return matches, nil
+	}
+	for _, e := range entries {
+		matched, err := filepath.Match(pattern, e.Name())
+		if err != nil {
+			return matches, err
+		}
+		if matched {
+			matches = append(matches, filepath.Join(dir, e.Name()))
+		}
+	}
+	return matches, nil
+}
+
+// globFS returns the names of all files in the filesystem matching the pattern, similar to [filepath.Glob].
+func globFS(fsys FS, pattern string) ([]string, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	if !hasGlobMeta(pattern) {
+		if _, err := fsys.Lstat(pattern); err != nil {
+			return nil, nil
+		}
+		return []string{pattern}, nil
+	}
+
+	dir, file := filepath.Split(pattern)
+	dir = filepath.Clean(dir)
+	if !hasGlobMeta(dir) {
+		return globDirFS(fsys, dir, file, nil)
+	}
+	if dir == pattern {
+		return nil, filepath.ErrBadPattern
+	}
+	dirs, err := globFS(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, d := range dirs {
+		if matches, err = globDirFS(fsys, d, file, matches); err != nil {
+			return nil, err
+		}
+	}
+	return matches, nil
+}
+
+// hasGlobMeta returns whether or not the path contains any of the magic characters recognized by [filepath.Match].
+func hasGlobMeta(path string) bool {
+	magic := `*?[`
+	if filepath.Separator != '\\' {
+		magic = `*?[\`
+	}
+	return strings.ContainsAny(path, magic)
+}
+
+// isOSFS returns whether or not the given filesystem is the operating system's filesystem.
+func isOSFS(fsys FS) bool {
+	_, ok := fsys.(OSFS)
+	return ok
+}
+
+// mkdirTemp creates a new temporary directory in the given directory of the filesystem, similar to [os.MkdirTemp].
+func mkdirTemp(fsys FS, dir, pattern string) (string, error) {
+	prefix, suffix := splitTempPattern(pattern)
+	for try := 0; ; try++ {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10)+suffix)
+		err := fsys.Mkdir(name, 0700)
+		if errors.Is(err, fs.ErrExist) && try < 10000 {
+			continue
+		}
+		return name, err
+	}
+}
+
+// readOnlyError returns the error used for operations which would modify a read-only filesystem.
+func readOnlyError(op, name string) error {
+	return &fs.PathError{Op: op, Path: name, Err: fs.ErrPermission}
+}
+
+// sameFile returns whether or not the two descriptions of files on the given filesystem describe the same file.
+//
+// Only the operating system's filesystem exposes the identity of a file, so files on any other filesystem are always
+// considered to be the same.
+func sameFile(fsys FS, a, b fs.FileInfo) bool {
+	if !isOSFS(fsys) {
+		return true
+	}
+	return os.SameFile(a, b)
+}
+
+// sameFS returns whether or not both filesystems are the same.
+//
+// Filesystems whose types cannot be compared are never considered to be the same.
+func sameFS(a, b FS) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// splitTempPattern splits a temporary file pattern into the prefix and suffix surrounding the last "*".
+func splitTempPattern(pattern string) (string, string) {
+	if i := strings.LastIndexByte(pattern, '*'); i >= 0 {
+		return pattern[:i], pattern[i+1:]
+	}
+	return pattern, ""
+}
+
+// toFSName converts the given filesystem path to the unrooted, slash-separated form required by [fs.FS].
+func toFSName(name string) string {
+	name = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(name)), filepath.ToSlash(filepath.VolumeName(name)))
+	name = strings.TrimLeft(path.Clean(name), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
+
+// walkDirFS recursively walks the given directory of the filesystem.
+func walkDirFS(fsys FS, name string, d fs.DirEntry, fn fs.WalkDirFunc) error {
+	if err := fn(name, d, nil); err != nil || !d.IsDir() {
+		if err == filepath.SkipDir && d.IsDir() {
+			err = nil
+		}
+		return err
+	}
+
+	entries, err := fsys.ReadDir(name)
+	if err != nil {
+		if err = fn(name, d, err); err != nil {
+			if err == filepath.SkipDir && d.IsDir() {
+				err = nil
+			}
+			return err
+		}
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	for _, e := range entries {
+		if err := walkDirFS(fsys, filepath.Join(name, e.Name()), e, fn); err != nil {
+			if err == filepath.SkipDir {
+				break
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// walkFS walks the file tree rooted at root in lexical order, similar to [filepath.WalkDir].
+func walkFS(fsys FS, root string, fn fs.WalkDirFunc) error {
+	info, err := fsys.Lstat(root)
+	if err != nil {
+		err = fn(root, nil, err)
+	} else {
+		err = walkDirFS(fsys, root, fs.FileInfoToDirEntry(info), fn)
+	}
+	if err == filepath.SkipDir || err == filepath.SkipAll {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,246 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// minCommitHashLength is the minimum number of characters allowed in an abbreviated commit hash.
+	minCommitHashLength = 7
+
+	// maxCommitHashLength is the length of a full SHA-256 commit hash.
+	maxCommitHashLength = 64
+
+	// sha1CommitHashLength is the length of a full SHA-1 commit hash.
+	sha1CommitHashLength = 40
+)
+
+// CommitHash represents a full or abbreviated Git commit hash.
+//
+// Hashes are stored in lowercase and must be between 7 and 64 hexadecimal characters long, which covers abbreviated,
+// SHA-1 and SHA-256 hashes.
+type CommitHash string
+
+// ParseCommitHash parses and validates the given string as a [CommitHash] object.
+func ParseCommitHash(hash string) (CommitHash, error) {
+	str := strings.ToLower(strings.TrimSpace(hash))
+	if !isCommitHash(str) {
+		return "", fmt.Errorf("invalid commit hash '%s': expected %d to %d hexadecimal characters", hash,
+			minCommitHashLength, maxCommitHashLength)
+	}
+	return CommitHash(str), nil
+}
+
+// Equal returns whether or not the two hashes refer to the same commit.
+//
+// If either hash is abbreviated, they are considered equal if the shorter hash is a prefix of the longer one.
+func (c CommitHash) Equal(c2 CommitHash) bool {
+	if c == "" || c2 == "" {
+		return c == c2
+	}
+	if len(c) > len(c2) {
+		return strings.HasPrefix(string(c), string(c2))
+	}
+	return strings.HasPrefix(string(c2), string(c))
+}
+
+// IsShort returns whether or not the hash is abbreviated rather than a full SHA-1 or SHA-256 hash.
+func (c CommitHash) IsShort() bool {
+	return len(c) != sha1CommitHashLength && len(c) != maxCommitHashLength
+}
+
+// MarshalJSON marshals the [CommitHash] object to JSON.
+func (c CommitHash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// MarshalText marshals the [CommitHash] object to plain text.
+func (c CommitHash) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Short returns the hash abbreviated to 7 characters.
+func (c CommitHash) Short() CommitHash {
+	if len(c) <= minCommitHashLength {
+		return c
+	}
+	return c[:minCommitHashLength]
+}
+
+// String returns the [CommitHash] object as a string.
+func (c CommitHash) String() string {
+	return string(c)
+}
+
+// UnmarshalJSON parses and validates the JSON data as a [CommitHash] object.
+func (c *CommitHash) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses and validates the text as a [CommitHash] object.
+func (c *CommitHash) UnmarshalText(data []byte) error {
+	hash, err := ParseCommitHash(string(data))
+	if err != nil {
+		return err
+	}
+	*c = hash
+	return nil
+}
+
+// GitRefKind identifies what a [GitRef] object refers to.
+type GitRefKind int
+
+const (
+	// GitRefBranch indicates the reference is a branch.
+	GitRefBranch GitRefKind = iota
+
+	// GitRefTag indicates the reference is a tag.
+	GitRefTag
+
+	// GitRefCommit indicates the reference is a commit hash.
+	GitRefCommit
+)
+
+// String returns the [GitRefKind] object as a string.
+func (k GitRefKind) String() string {
+	switch k {
+	case GitRefBranch:
+		return "branch"
+	case GitRefTag:
+		return "tag"
+	case GitRefCommit:
+		return "commit"
+	default:
+		return "unknown"
+	}
+}
+
+// GitRef represents a Git branch, tag or commit.
+type GitRef struct {
+	kind GitRefKind
+	name string
+}
+
+// ParseGitRef parses the given string into a [GitRef] object.
+//
+// Strings starting with "refs/heads/" are branches and strings starting with "refs/tags/" are tags. Otherwise,
+// strings made up of 7 to 64 hexadecimal characters are commits and anything else is a branch. Branch and tag
+// names must follow the rules of git check-ref-format.
+func ParseGitRef(ref string) (GitRef, error) {
+	str := strings.TrimSpace(ref)
+	r := GitRef{kind: GitRefBranch, name: str}
+	switch {
+	case strings.HasPrefix(str, "refs/heads/"):
+		r.name = strings.TrimPrefix(str, "refs/heads/")
+	case strings.HasPrefix(str, "refs/tags/"):
+		r.kind, r.name = GitRefTag, strings.TrimPrefix(str, "refs/tags/")
+	case isCommitHash(strings.ToLower(str)):
+		return GitRef{kind: GitRefCommit, name: strings.ToLower(str)}, nil
+	}
+	if err := validateGitRefName(r.name); err != nil {
+		return GitRef{}, fmt.Errorf("invalid Git reference '%s': %w", ref, err)
+	}
+	return r, nil
+}
+
+// Commit returns the commit hash the reference refers to and true if it is a commit reference.
+func (r GitRef) Commit() (CommitHash, bool) {
+	if r.kind != GitRefCommit {
+		return "", false
+	}
+	return CommitHash(r.name), true
+}
+
+// Kind returns what the reference refers to.
+func (r GitRef) Kind() GitRefKind {
+	return r.kind
+}
+
+// MarshalJSON marshals the [GitRef] object to JSON.
+func (r GitRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// MarshalText marshals the [GitRef] object to plain text.
+func (r GitRef) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Name returns the short name of the branch or tag, such as "main", or the commit hash.
+func (r GitRef) Name() string {
+	return r.name
+}
+
+// String returns the [GitRef] object as a fully qualified reference, such as "refs/heads/main" or "refs/tags/v1.0.0",
+// or as the commit hash.
+func (r GitRef) String() string {
+	switch {
+	case r.name == "":
+		return ""
+	case r.kind == GitRefBranch:
+		return "refs/heads/" + r.name
+	case r.kind == GitRefTag:
+		return "refs/tags/" + r.name
+	}
+	return r.name
+}
+
+// UnmarshalJSON parses the JSON data into a [GitRef] object.
+func (r *GitRef) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return r.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [GitRef] object.
+func (r *GitRef) UnmarshalText(data []byte) error {
+	ref, err := ParseGitRef(string(data))
+	if err != nil {
+		return err
+	}
+	*r = ref
+	return nil
+}
+
+// isCommitHash returns whether or not the given lowercase string is a valid commit hash.
+func isCommitHash(str string) bool {
+	if len(str) < minCommitHashLength || len(str) > maxCommitHashLength {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		if (str[i] < '0' || str[i] > '9') && (str[i] < 'a' || str[i] > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// validateGitRefName returns an error if the given branch or tag name breaks the rules of git check-ref-format.
+func validateGitRefName(name string) error {
+	switch {
+	case name == "" || name == "@":
+		return fmt.Errorf("reference name '%s' is not allowed", name)
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/") || strings.Contains(name, "//"):
+		return fmt.Errorf("reference name must not start or end with '/' or contain '//'")
+	case strings.HasSuffix(name, ".") || strings.HasSuffix(name, ".lock"):
+		return fmt.Errorf("reference name must not end with '.' or '.lock'")
+	case strings.Contains(name, "..") || strings.Contains(name, "@{") || strings.Contains(name, "/."):
+		return fmt.Errorf("reference name must not contain '..', '@{' or '/.'")
+	case strings.HasPrefix(name, "."):
+		return fmt.Errorf("reference name must not start with '.'")
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Errorf("reference name contains invalid character '%c'", r)
+		}
+	}
+	return nil
+}
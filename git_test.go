@@ -0,0 +1,54 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestCommitHash1(t *testing.T) {
+	full, err := types.ParseCommitHash("1C16872A9D3B5E7F0123456789ABCDEF01234567")
+	if err != nil {
+		t.Fatalf("failed to parse commit hash: %v", err)
+	}
+	short, _ := types.ParseCommitHash("1c16872")
+	if full.IsShort() || !short.IsShort() || full.Short() != short {
+		t.Errorf("unexpected short forms: %s / %s", full, short)
+	}
+	if !full.Equal(short) || !short.Equal(full) || full.Equal("1c16873") {
+		t.Errorf("unexpected prefix matching results")
+	}
+	for _, str := range []string{"1c1687", "xyz1234", ""} {
+		if _, err := types.ParseCommitHash(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
+
+func TestGitRef1(t *testing.T) {
+	var deploy struct {
+		Refs []types.GitRef `json:"refs"`
+	}
+	data := `{"refs": ["main", "refs/tags/v1.2.0", "refs/heads/feature/login", "1c16872"]}`
+	if err := json.Unmarshal([]byte(data), &deploy); err != nil {
+		t.Fatalf("failed to unmarshal Git references: %v", err)
+	}
+	kinds := []types.GitRefKind{types.GitRefBranch, types.GitRefTag, types.GitRefBranch, types.GitRefCommit}
+	for i, ref := range deploy.Refs {
+		if ref.Kind() != kinds[i] {
+			t.Errorf("expected %s to be a %s but got %s", ref, kinds[i], ref.Kind())
+		}
+	}
+	if hash, ok := deploy.Refs[3].Commit(); !ok || hash != "1c16872" {
+		t.Errorf("unexpected commit: %s", hash)
+	}
+	out, _ := json.Marshal(deploy)
+	t.Logf("refs: %s", out)
+
+	for _, str := range []string{"bad..name", "refs/heads/", "with space", "ends.lock", "a:b"} {
+		if _, err := types.ParseGitRef(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
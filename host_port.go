@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// HostPort represents a network address made up of a host name or IP address and a port.
+//
+// It is parsed from strings such as "example.com:443", "10.0.0.1:8080", "[::1]:53" or ":8080", as well as bare hosts
+// such as "example.com" or "::1" when a default port is available.
+type HostPort struct {
+	host string
+	port int
+}
+
+// NewHostPort creates a new HostPort object from the given host and port.
+//
+// The port must be between 1 and 65535, inclusively.
+func NewHostPort(host string, port int) (HostPort, error) {
+	if err := validatePort(port); err != nil {
+		return HostPort{}, err
+	}
+	return HostPort{host: host, port: port}, nil
+}
+
+// ParseHostPort parses the given string into a [HostPort] object.
+//
+// If the string does not include a port, defaultPort is used instead. A defaultPort of 0 means that a port is
+// required.
+func ParseHostPort(hostport string, defaultPort int) (HostPort, error) {
+	str := strings.TrimSpace(hostport)
+	host, portStr := str, ""
+	switch {
+	case strings.HasPrefix(str, "["):
+		end := strings.Index(str, "]")
+		if end < 0 {
+			return HostPort{}, fmt.Errorf("failed to parse host and port '%s': missing ']' in address", hostport)
+		}
+		host = str[1:end]
+		if rest := str[end+1:]; rest != "" {
+			var found bool
+			if portStr, found = strings.CutPrefix(rest, ":"); !found {
+				return HostPort{}, fmt.Errorf("failed to parse host and port '%s': unexpected '%s' after address",
+					hostport, rest)
+			}
+		}
+	case strings.Count(str, ":") == 1:
+		host, portStr, _ = strings.Cut(str, ":")
+	}
+
+	port := defaultPort
+	if portStr != "" {
+		p, err := strconv.Atoi(portStr)
+		if err != nil {
+			return HostPort{}, fmt.Errorf("failed to parse host and port '%s': invalid port '%s'", hostport, portStr)
+		}
+		port = p
+	}
+	if err := validatePort(port); err != nil {
+		return HostPort{}, fmt.Errorf("failed to parse host and port '%s': %w", hostport, err)
+	}
+	return HostPort{host: host, port: port}, nil
+}
+
+// Host returns the host name or IP address, without any brackets around IPv6 addresses.
+func (h HostPort) Host() string {
+	return h.host
+}
+
+// MarshalJSON marshals the [HostPort] object to JSON.
+func (h HostPort) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.String())
+}
+
+// MarshalText marshals the [HostPort] object to plain text.
+func (h HostPort) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// Port returns the port number.
+func (h HostPort) Port() int {
+	return h.port
+}
+
+// String returns the [HostPort] object as a string suitable for passing to [net.Dial], such as "[::1]:53".
+func (h HostPort) String() string {
+	if h.host == "" && h.port == 0 {
+		return ""
+	}
+	return net.JoinHostPort(h.host, strconv.Itoa(h.port))
+}
+
+// UnmarshalJSON parses the JSON data into a [HostPort] object.
+//
+// If the address does not include a port, the port already stored in the object is used as the default.
+func (h *HostPort) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return h.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [HostPort] object.
+//
+// If the address does not include a port, the port already stored in the object is used as the default.
+func (h *HostPort) UnmarshalText(data []byte) error {
+	hp, err := ParseHostPort(string(data), h.port)
+	if err != nil {
+		return err
+	}
+	*h = hp
+	return nil
+}
+
+// validatePort returns an error if the given port is not between 1 and 65535, inclusively.
+func validatePort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("port %d must be between 1 and 65535, inclusively", port)
+	}
+	return nil
+}
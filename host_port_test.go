@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestHostPort1(t *testing.T) {
+	for str, expected := range map[string]string{
+		"example.com:443": "example.com:443",
+		"example.com":     "example.com:8080",
+		"[::1]:53":        "[::1]:53",
+		"[::1]":           "[::1]:8080",
+		"::1":             "[::1]:8080",
+		":9000":           ":9000",
+	} {
+		hp, err := types.ParseHostPort(str, 8080)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %v", str, err)
+			continue
+		}
+		if hp.String() != expected {
+			t.Errorf("expected '%s' to parse as '%s' but got '%s'", str, expected, hp)
+		}
+	}
+	for _, str := range []string{"example.com:0", "example.com:65536", "example.com:http", "[::1", "example.com"} {
+		if _, err := types.ParseHostPort(str, 0); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+
+	config := struct {
+		Listen types.HostPort `json:"listen"`
+	}{}
+	config.Listen, _ = types.NewHostPort("", 8443)
+	if err := json.Unmarshal([]byte(`{"listen": "0.0.0.0"}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal host and port: %v", err)
+	}
+	if config.Listen.Host() != "0.0.0.0" || config.Listen.Port() != 8443 {
+		t.Errorf("unexpected host and port: %s", config.Listen)
+	}
+}
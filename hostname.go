@@ -0,0 +1,207 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// maxHostnameLength is the maximum length of a hostname in its ASCII form, excluding any trailing dot.
+const maxHostnameLength = 253
+
+var (
+	// idnaToASCII is the function used to convert internationalized hostnames to their ASCII form.
+	idnaToASCII func(string) (string, error)
+
+	// idnaMu guards access to idnaToASCII.
+	idnaMu sync.RWMutex
+)
+
+// RegisterIDNA registers the function used to convert internationalized hostnames to their ASCII form.
+//
+// By default, hostnames are lowercased and each label containing non-ASCII characters is encoded with Punycode,
+// which does not apply the full IDNA mapping and normalization rules. For complete IDNA support, register a
+// conversion function from an IDNA library:
+//
+//	types.RegisterIDNA(idna.Lookup.ToASCII)
+func RegisterIDNA(toASCII func(string) (string, error)) {
+	idnaMu.Lock()
+	defer idnaMu.Unlock()
+	idnaToASCII = toASCII
+}
+
+// Hostname represents a host name which has been validated against the rules of RFC 1123.
+//
+// Hostnames are stored in their lowercase ASCII form without any trailing dot. Internationalized names such as
+// "bücher.example" are converted to their Punycode form, such as "xn--bcher-kva.example".
+type Hostname string
+
+// ParseHostname parses and validates the given string as a [Hostname] object.
+//
+// Each label must be between 1 and 63 characters long, contain only letters, digits and hyphens and must not start
+// or end with a hyphen. The whole name must be no more than 253 characters long. If requireFQDN is true, the name
+// must also contain at least two labels.
+func ParseHostname(hostname string, requireFQDN bool) (Hostname, error) {
+	str := strings.TrimSpace(hostname)
+	if !isASCII(str) {
+		var err error
+		if str, err = hostnameToASCII(str); err != nil {
+			return "", fmt.Errorf("failed to convert hostname '%s' to ASCII: %w", hostname, err)
+		}
+	}
+	str = strings.TrimSuffix(strings.ToLower(str), ".")
+
+	if str == "" {
+		return "", fmt.Errorf("invalid hostname '%s': hostname is empty", hostname)
+	}
+	if len(str) > maxHostnameLength {
+		return "", fmt.Errorf("invalid hostname '%s': hostname is longer than %d characters", hostname,
+			maxHostnameLength)
+	}
+	labels := strings.Split(str, ".")
+	if requireFQDN && len(labels) < 2 {
+		return "", fmt.Errorf("invalid hostname '%s': a fully qualified domain name is required", hostname)
+	}
+	for _, label := range labels {
+		if err := validateHostnameLabel(label); err != nil {
+			return "", fmt.Errorf("invalid hostname '%s': %w", hostname, err)
+		}
+	}
+	return Hostname(str), nil
+}
+
+// IsFQDN returns whether or not the hostname contains at least two labels.
+func (h Hostname) IsFQDN() bool {
+	return strings.Contains(string(h), ".")
+}
+
+// Labels returns the dot-separated labels of the hostname.
+func (h Hostname) Labels() []string {
+	if h == "" {
+		return nil
+	}
+	return strings.Split(string(h), ".")
+}
+
+// MarshalJSON marshals the [Hostname] object to JSON.
+func (h Hostname) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.String())
+}
+
+// MarshalText marshals the [Hostname] object to plain text.
+func (h Hostname) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// String returns the [Hostname] object as a string.
+func (h Hostname) String() string {
+	return string(h)
+}
+
+// UnmarshalJSON parses and validates the JSON data as a [Hostname] object.
+func (h *Hostname) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return h.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses and validates the text as a [Hostname] object.
+func (h *Hostname) UnmarshalText(data []byte) error {
+	hostname, err := ParseHostname(string(data), false)
+	if err != nil {
+		return err
+	}
+	*h = hostname
+	return nil
+}
+
+// FQDN is a [Hostname] which is required to be a fully qualified domain name with at least two labels.
+type FQDN Hostname
+
+// Hostname returns the [FQDN] object as a [Hostname] object.
+func (f FQDN) Hostname() Hostname {
+	return Hostname(f)
+}
+
+// MarshalJSON marshals the [FQDN] object to JSON.
+func (f FQDN) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// MarshalText marshals the [FQDN] object to plain text.
+func (f FQDN) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// String returns the [FQDN] object as a string.
+func (f FQDN) String() string {
+	return string(f)
+}
+
+// UnmarshalJSON parses and validates the JSON data as a [FQDN] object.
+func (f *FQDN) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return f.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses and validates the text as a [FQDN] object.
+func (f *FQDN) UnmarshalText(data []byte) error {
+	hostname, err := ParseHostname(string(data), true)
+	if err != nil {
+		return err
+	}
+	*f = FQDN(hostname)
+	return nil
+}
+
+// hostnameToASCII converts the given internationalized hostname to its ASCII form using the registered IDNA function
+// or the built-in Punycode encoder.
+func hostnameToASCII(hostname string) (string, error) {
+	idnaMu.RLock()
+	toASCII := idnaToASCII
+	idnaMu.RUnlock()
+	if toASCII != nil {
+		return toASCII(hostname)
+	}
+
+	// IDNA treats these full-width and ideographic full stops as label separators
+	hostname = strings.NewReplacer("。", ".", "．", ".", "｡", ".").Replace(hostname)
+	labels := strings.Split(strings.ToLower(hostname), ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		encoded, err := encodePunycode(label)
+		if err != nil {
+			return "", err
+		}
+		labels[i] = "xn--" + encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// validateHostnameLabel returns an error if the given label does not follow the rules of RFC 1123.
+func validateHostnameLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("hostname contains an empty label")
+	}
+	if len(label) > 63 {
+		return fmt.Errorf("label '%s' is longer than 63 characters", label)
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("label '%s' must not start or end with a hyphen", label)
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return fmt.Errorf("label '%s' contains invalid character '%c'", label, c)
+		}
+	}
+	return nil
+}
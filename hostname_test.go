@@ -0,0 +1,49 @@
+package types_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestHostname1(t *testing.T) {
+	for str, expected := range map[string]string{
+		"Example.COM.":     "example.com",
+		"localhost":        "localhost",
+		"bücher.example":   "xn--bcher-kva.example",
+		"München.de":       "xn--mnchen-3ya.de",
+		"例え.テスト":           "xn--r8jz45g.xn--zckzah",
+		"3com.example.net": "3com.example.net",
+	} {
+		h, err := types.ParseHostname(str, false)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %v", str, err)
+			continue
+		}
+		if h.String() != expected {
+			t.Errorf("expected '%s' to parse as '%s' but got '%s'", str, expected, h)
+		}
+	}
+	for _, str := range []string{"", "-bad.example", "bad-.example", "under_score.example", "a..b",
+		strings.Repeat("a", 64) + ".example", "spaces here.example"} {
+		if _, err := types.ParseHostname(str, false); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+
+	var config struct {
+		Host   types.Hostname `json:"host"`
+		Domain types.FQDN     `json:"domain"`
+	}
+	if err := json.Unmarshal([]byte(`{"host": "db01", "domain": "db01"}`), &config); err == nil {
+		t.Errorf("expected single-label domain to be rejected")
+	}
+	if err := json.Unmarshal([]byte(`{"host": "db01", "domain": "db01.example.com"}`), &config); err != nil {
+		t.Errorf("failed to unmarshal hostnames: %v", err)
+	}
+	if config.Host.IsFQDN() || !config.Domain.Hostname().IsFQDN() {
+		t.Errorf("unexpected FQDN results: %+v", config)
+	}
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"slices"
+	"time"
+)
+
+// ErrInvalidInterval is returned when an interval's start is not before its end.
+var ErrInvalidInterval = errors.New("interval start must be before its end")
+
+// IntervalMapEntry is a single half-open interval [Start, End) and the value it maps to.
+type IntervalMapEntry[K, V any] struct {
+	// Start is the inclusive start of the interval.
+	Start K
+
+	// End is the exclusive end of the interval.
+	End K
+
+	// Value is the value associated with the interval.
+	Value V
+}
+
+// IntervalMap is a generic map from half-open intervals to values which supports point and overlap queries.
+//
+// Intervals may overlap one another, in which case queries return every matching entry ordered by start. Use
+// [NewIntervalMap] for numeric or string keys and [NewTimeIntervalMap] for [time.Time] keys.
+//
+// Entries are kept sorted by start, so queries take O(log n + m) time for m candidate entries which start at or
+// before the queried point. IntervalMap objects are not safe for concurrent use.
+type IntervalMap[K, V any] struct {
+	compare func(a, b K) int
+	entries []IntervalMapEntry[K, V]
+}
+
+// NewIntervalMap creates a new, empty IntervalMap object for ordered keys such as numbers or strings.
+func NewIntervalMap[K cmp.Ordered, V any]() *IntervalMap[K, V] {
+	return &IntervalMap[K, V]{compare: cmp.Compare[K]}
+}
+
+// NewTimeIntervalMap creates a new, empty IntervalMap object for time ranges.
+func NewTimeIntervalMap[V any]() *IntervalMap[time.Time, V] {
+	return &IntervalMap[time.Time, V]{compare: time.Time.Compare}
+}
+
+// All returns an iterator over the entries in the map ordered by start.
+func (m *IntervalMap[K, V]) All() iter.Seq[IntervalMapEntry[K, V]] {
+	return slices.Values(m.entries)
+}
+
+// Find returns the entries whose intervals contain the given point.
+func (m *IntervalMap[K, V]) Find(point K) []IntervalMapEntry[K, V] {
+	var result []IntervalMapEntry[K, V]
+	for _, e := range m.entries[:m.startsAfter(point, false)] {
+		if m.compare(point, e.End) < 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Insert maps the half-open interval [start, end) to the given value.
+//
+// If start is not before end, [ErrInvalidInterval] is returned.
+func (m *IntervalMap[K, V]) Insert(start, end K, value V) error {
+	if m.compare(start, end) >= 0 {
+		return ErrInvalidInterval
+	}
+	i := m.startsAfter(start, false)
+	m.entries = slices.Insert(m.entries, i, IntervalMapEntry[K, V]{Start: start, End: end, Value: value})
+	return nil
+}
+
+// Len returns the number of entries in the map.
+func (m *IntervalMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Overlapping returns the entries whose intervals overlap the half-open interval [start, end).
+func (m *IntervalMap[K, V]) Overlapping(start, end K) []IntervalMapEntry[K, V] {
+	var result []IntervalMapEntry[K, V]
+	for _, e := range m.entries[:m.startsAfter(end, true)] {
+		if m.compare(start, e.End) < 0 {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Remove deletes the entries whose intervals are exactly [start, end) and returns the number removed.
+func (m *IntervalMap[K, V]) Remove(start, end K) int {
+	n := len(m.entries)
+	m.entries = slices.DeleteFunc(m.entries, func(e IntervalMapEntry[K, V]) bool {
+		return m.compare(e.Start, start) == 0 && m.compare(e.End, end) == 0
+	})
+	return n - len(m.entries)
+}
+
+// startsAfter returns the index of the first entry which starts after the given key or, if orEqual is true, at or
+// after it.
+func (m *IntervalMap[K, V]) startsAfter(key K, orEqual bool) int {
+	i, _ := slices.BinarySearchFunc(m.entries, key, func(e IntervalMapEntry[K, V], k K) int {
+		if c := m.compare(e.Start, k); c != 0 || orEqual {
+			return c
+		}
+		return -1
+	})
+	return i
+}
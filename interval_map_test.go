@@ -0,0 +1,47 @@
+package types_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestIntervalMap1(t *testing.T) {
+	tiers := types.NewIntervalMap[int, string]()
+	tiers.Insert(0, 1000, "free")
+	tiers.Insert(1000, 10000, "standard")
+	tiers.Insert(10000, math.MaxInt32, "enterprise")
+	tiers.Insert(500, 1500, "promo")
+	if err := tiers.Insert(5, 5, "empty"); err != types.ErrInvalidInterval {
+		t.Errorf("expected empty interval to be rejected but got: %v", err)
+	}
+
+	for point, expected := range map[int]int{0: 1, 999: 2, 1000: 2, 1500: 1, 10000: 1, -1: 0} {
+		if found := tiers.Find(point); len(found) != expected {
+			t.Errorf("expected %d entries containing %d but got %v", expected, point, found)
+		}
+	}
+	if overlap := tiers.Overlapping(900, 1000); len(overlap) != 2 {
+		t.Errorf("unexpected overlapping entries: %v", overlap)
+	}
+	if tiers.Remove(500, 1500) != 1 || tiers.Len() != 3 {
+		t.Errorf("expected promo tier to be removed")
+	}
+}
+
+func TestIntervalMap2(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	windows := types.NewTimeIntervalMap[string]()
+	windows.Insert(day.Add(2*time.Hour), day.Add(4*time.Hour), "database patching")
+	windows.Insert(day.Add(3*time.Hour), day.Add(5*time.Hour), "network upgrade")
+
+	found := windows.Find(day.Add(3*time.Hour + 30*time.Minute))
+	if len(found) != 2 || found[0].Value != "database patching" {
+		t.Errorf("unexpected maintenance windows: %v", found)
+	}
+	if found := windows.Overlapping(day, day.Add(2*time.Hour)); len(found) != 0 {
+		t.Errorf("expected no windows before 02:00 but got: %v", found)
+	}
+}
@@ -0,0 +1,166 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// earthRadiusMeters is the mean radius of the Earth in meters.
+const earthRadiusMeters = 6371008.8
+
+var (
+	// dmsPattern matches a single coordinate in degrees, minutes and seconds, such as 40°42'46.1"N.
+	dmsPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*°\s*(?:(\d+(?:\.\d+)?)\s*['′]\s*)?(?:(\d+(?:\.\d+)?)\s*["″]\s*)?([NSEWnsew])`)
+
+	// latLongSeparatorPattern matches the separator between the latitude and longitude in decimal form.
+	latLongSeparatorPattern = regexp.MustCompile(`\s*,\s*|\s+`)
+)
+
+// LatLong represents a geographic coordinate as a latitude and longitude in decimal degrees.
+//
+// It is marshaled to JSON as an object with "lat" and "lon" fields and can be unmarshaled from either that object or
+// from any string accepted by [ParseLatLong].
+type LatLong struct {
+	// Lat is the latitude in decimal degrees, from -90 (south) to 90 (north).
+	Lat float64 `json:"lat" yaml:"lat" mapstructure:"lat"`
+
+	// Lon is the longitude in decimal degrees, from -180 (west) to 180 (east).
+	Lon float64 `json:"lon" yaml:"lon" mapstructure:"lon"`
+}
+
+// NewLatLong creates a new LatLong object from the given latitude and longitude, validating their ranges.
+func NewLatLong(lat, lon float64) (LatLong, error) {
+	ll := LatLong{Lat: lat, Lon: lon}
+	if err := ll.Validate(); err != nil {
+		return LatLong{}, err
+	}
+	return ll, nil
+}
+
+// ParseLatLong parses the given string into a [LatLong] object.
+//
+// The string may be a pair of decimal degrees separated by a comma or space, such as "40.7128,-74.0060", or a pair of
+// coordinates in degrees, minutes and seconds with hemisphere letters, such as 40°42'46.1"N 74°0'21.6"W.
+func ParseLatLong(latLong string) (LatLong, error) {
+	str := strings.TrimSpace(latLong)
+
+	if matches := dmsPattern.FindAllStringSubmatch(str, -1); len(matches) > 0 {
+		if len(matches) != 2 || strings.Trim(dmsPattern.ReplaceAllString(str, ""), " ,") != "" {
+			return LatLong{}, fmt.Errorf("failed to parse coordinates '%s': expected a latitude and longitude",
+				latLong)
+		}
+		var ll LatLong
+		for _, m := range matches {
+			value := parseDMS(m[1], m[2], m[3])
+			switch strings.ToUpper(m[4]) {
+			case "N":
+				ll.Lat = value
+			case "S":
+				ll.Lat = -value
+			case "E":
+				ll.Lon = value
+			case "W":
+				ll.Lon = -value
+			}
+		}
+		if hemi := strings.ToUpper(matches[0][4] + matches[1][4]); hemi != "NE" && hemi != "NW" && hemi != "SE" &&
+			hemi != "SW" {
+			return LatLong{}, fmt.Errorf("failed to parse coordinates '%s': expected a latitude followed by a "+
+				"longitude", latLong)
+		}
+		if err := ll.Validate(); err != nil {
+			return LatLong{}, fmt.Errorf("failed to parse coordinates '%s': %w", latLong, err)
+		}
+		return ll, nil
+	}
+
+	parts := latLongSeparatorPattern.Split(str, -1)
+	if len(parts) != 2 {
+		return LatLong{}, fmt.Errorf("failed to parse coordinates '%s': expected 'latitude,longitude'", latLong)
+	}
+	lat, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return LatLong{}, fmt.Errorf("failed to parse latitude '%s': %w", parts[0], err)
+	}
+	lon, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return LatLong{}, fmt.Errorf("failed to parse longitude '%s': %w", parts[1], err)
+	}
+	ll, err := NewLatLong(lat, lon)
+	if err != nil {
+		return LatLong{}, fmt.Errorf("failed to parse coordinates '%s': %w", latLong, err)
+	}
+	return ll, nil
+}
+
+// Distance returns the great-circle distance to the given coordinate in meters.
+//
+// The distance is computed with the haversine formula using the mean radius of the Earth, so it may differ from the
+// true distance by up to about 0.5%.
+func (l LatLong) Distance(l2 LatLong) float64 {
+	lat1, lat2 := l.Lat*math.Pi/180, l2.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (l2.Lon - l.Lon) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// String returns the [LatLong] object as a string in decimal degrees, such as "40.7128,-74.006".
+func (l LatLong) String() string {
+	return strconv.FormatFloat(l.Lat, 'f', -1, 64) + "," + strconv.FormatFloat(l.Lon, 'f', -1, 64)
+}
+
+// UnmarshalJSON parses the JSON data into a [LatLong] object.
+//
+// The data may be an object with "lat" and "lon" fields or a string accepted by [ParseLatLong].
+func (l *LatLong) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err == nil {
+		return l.UnmarshalText([]byte(sval))
+	}
+
+	// use a separate type to avoid recursing into this function
+	type latLong LatLong
+	var obj latLong
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if err := LatLong(obj).Validate(); err != nil {
+		return err
+	}
+	*l = LatLong(obj)
+	return nil
+}
+
+// UnmarshalText parses the text into a [LatLong] object.
+func (l *LatLong) UnmarshalText(data []byte) error {
+	ll, err := ParseLatLong(string(data))
+	if err != nil {
+		return err
+	}
+	*l = ll
+	return nil
+}
+
+// Validate returns an error if the latitude or longitude is out of range.
+func (l LatLong) Validate() error {
+	if math.IsNaN(l.Lat) || l.Lat < -90 || l.Lat > 90 {
+		return fmt.Errorf("latitude %g must be between -90 and 90, inclusively", l.Lat)
+	}
+	if math.IsNaN(l.Lon) || l.Lon < -180 || l.Lon > 180 {
+		return fmt.Errorf("longitude %g must be between -180 and 180, inclusively", l.Lon)
+	}
+	return nil
+}
+
+// parseDMS converts the given degrees, minutes and seconds into decimal degrees.
+func parseDMS(degrees, minutes, seconds string) float64 {
+	d, _ := strconv.ParseFloat(degrees, 64)
+	m, _ := strconv.ParseFloat(minutes, 64)
+	s, _ := strconv.ParseFloat(seconds, 64)
+	return d + m/60 + s/3600
+}
@@ -0,0 +1,47 @@
+package types_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestLatLong1(t *testing.T) {
+	nyc, err := types.ParseLatLong("40.7128,-74.0060")
+	if err != nil {
+		t.Fatalf("failed to parse decimal coordinates: %v", err)
+	}
+	dms, err := types.ParseLatLong(`40°42'46.1"N 74°0'21.6"W`)
+	if err != nil {
+		t.Fatalf("failed to parse DMS coordinates: %v", err)
+	}
+	if d := nyc.Distance(dms); d > 5 {
+		t.Errorf("expected decimal and DMS coordinates to be within 5m but got %.1fm", d)
+	}
+	london, _ := types.NewLatLong(51.5074, -0.1278)
+	if d := nyc.Distance(london) / 1000; math.Abs(d-5570) > 10 {
+		t.Errorf("expected New York to London to be about 5570km but got %.0fkm", d)
+	}
+	for _, str := range []string{"91,0", "0,181", "40.7", `74°0'21.6"W 40°42'46.1"N`, "abc,def"} {
+		if _, err := types.ParseLatLong(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+
+	var fence struct {
+		Center types.LatLong `json:"center"`
+		Origin types.LatLong `json:"origin"`
+	}
+	if err := json.Unmarshal([]byte(`{"center": {"lat": 51.5074, "lon": -0.1278}, "origin": "40.7128 -74.006"}`), &fence); err != nil {
+		t.Fatalf("failed to unmarshal coordinates: %v", err)
+	}
+	out, _ := json.Marshal(fence)
+	if string(out) != `{"center":{"lat":51.5074,"lon":-0.1278},"origin":{"lat":40.7128,"lon":-74.006}}` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+	if err := json.Unmarshal([]byte(`{"center": {"lat": 100, "lon": 0}}`), &fence); err == nil {
+		t.Errorf("expected out of range latitude to be rejected")
+	}
+}
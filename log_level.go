@@ -0,0 +1,132 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// LogLevel represents the severity of a log message.
+//
+// Its numeric values match [slog.Level], with two additional levels: [LogLevelTrace] below debug and [LogLevelFatal]
+// above error.
+type LogLevel int
+
+const (
+	// LogLevelTrace is the level for very detailed diagnostic messages.
+	LogLevelTrace LogLevel = -8
+
+	// LogLevelDebug is the level for diagnostic messages.
+	LogLevelDebug LogLevel = LogLevel(slog.LevelDebug)
+
+	// LogLevelInfo is the level for informational messages.
+	LogLevelInfo LogLevel = LogLevel(slog.LevelInfo)
+
+	// LogLevelWarn is the level for warning messages.
+	LogLevelWarn LogLevel = LogLevel(slog.LevelWarn)
+
+	// LogLevelError is the level for error messages.
+	LogLevelError LogLevel = LogLevel(slog.LevelError)
+
+	// LogLevelFatal is the level for messages logged just before the program exits.
+	LogLevelFatal LogLevel = 12
+)
+
+// logLevelNames maps each named level to its lowercase name, ordered from lowest to highest.
+var logLevelNames = []struct {
+	level LogLevel
+	name  string
+}{
+	{LogLevelTrace, "trace"},
+	{LogLevelDebug, "debug"},
+	{LogLevelInfo, "info"},
+	{LogLevelWarn, "warn"},
+	{LogLevelError, "error"},
+	{LogLevelFatal, "fatal"},
+}
+
+// ParseLogLevel parses the given string into a [LogLevel] object.
+//
+// The string may be a level name such as "debug" or "WARN", a name with a numeric offset such as "info+2" or
+// "error-1", or an integer. Names are not case sensitive and "warning" is accepted as an alias of "warn".
+func ParseLogLevel(level string) (LogLevel, error) {
+	str := strings.ToLower(strings.TrimSpace(level))
+	if n, err := strconv.Atoi(str); err == nil {
+		return LogLevel(n), nil
+	}
+
+	name, offset := str, 0
+	if i := strings.IndexAny(str, "+-"); i > 0 {
+		n, err := strconv.Atoi(str[i:])
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse log level '%s': invalid offset '%s'", level, str[i:])
+		}
+		name, offset = str[:i], n
+	}
+	if name == "warning" {
+		name = "warn"
+	}
+	for _, l := range logLevelNames {
+		if l.name == name {
+			return l.level + LogLevel(offset), nil
+		}
+	}
+	return 0, fmt.Errorf("failed to parse log level '%s': expected trace, debug, info, warn, error or fatal", level)
+}
+
+// Level returns the [slog.Level] equivalent of the object, which allows it to be used as a [slog.Leveler].
+func (l LogLevel) Level() slog.Level {
+	return slog.Level(l)
+}
+
+// MarshalJSON marshals the [LogLevel] object to JSON.
+func (l LogLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
+
+// MarshalText marshals the [LogLevel] object to plain text.
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// String returns the [LogLevel] object as a lowercase name, such as "info", with an offset from the nearest lower
+// named level if necessary, such as "warn+2".
+func (l LogLevel) String() string {
+	base := logLevelNames[0]
+	for _, n := range logLevelNames {
+		if n.level <= l {
+			base = n
+		}
+	}
+	if l == base.level {
+		return base.name
+	}
+	return fmt.Sprintf("%s%+d", base.name, l-base.level)
+}
+
+// UnmarshalJSON parses the JSON data into a [LogLevel] object.
+func (l *LogLevel) UnmarshalJSON(data []byte) error {
+	var ival int
+	if err := json.Unmarshal(data, &ival); err == nil {
+		*l = LogLevel(ival)
+		return nil
+	}
+
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return l.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [LogLevel] object.
+func (l *LogLevel) UnmarshalText(data []byte) error {
+	level, err := ParseLogLevel(string(data))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
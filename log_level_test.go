@@ -0,0 +1,40 @@
+package types_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestLogLevel1(t *testing.T) {
+	for str, expected := range map[string]string{
+		"DEBUG": "debug", "Warning": "warn", "info+2": "info+2", "error-1": "warn+3", "trace": "trace",
+		"fatal": "fatal", "-12": "trace-4", "8": "error",
+	} {
+		level, err := types.ParseLogLevel(str)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %v", str, err)
+			continue
+		}
+		if level.String() != expected {
+			t.Errorf("expected '%s' to be '%s' but got '%s'", str, expected, level)
+		}
+	}
+	if _, err := types.ParseLogLevel("verbose"); err == nil {
+		t.Errorf("expected unknown level to fail")
+	}
+
+	var config struct {
+		Level types.LogLevel `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(`{"level": "warn"}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal log level: %v", err)
+	}
+	handler := slog.NewTextHandler(nil, &slog.HandlerOptions{Level: config.Level})
+	if handler.Enabled(context.Background(), slog.LevelInfo) || !handler.Enabled(context.Background(), slog.LevelError) {
+		t.Errorf("expected handler to honor the configured level")
+	}
+}
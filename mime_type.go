@@ -0,0 +1,115 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"mime"
+	"strings"
+)
+
+// MIMEType represents a media type such as "text/html; charset=utf-8".
+//
+// The type, subtype and parameter names are normalized to lowercase when parsed.
+type MIMEType struct {
+	params  map[string]string
+	subtype string
+	typ     string
+}
+
+// ParseMIMEType parses the given string into a [MIMEType] object.
+//
+// The string must contain both a type and a subtype, optionally followed by parameters as described in RFC 2045.
+func ParseMIMEType(mimeType string) (MIMEType, error) {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return MIMEType{}, fmt.Errorf("failed to parse MIME type '%s': %w", mimeType, err)
+	}
+	typ, subtype, found := strings.Cut(mediaType, "/")
+	if !found || typ == "" || subtype == "" {
+		return MIMEType{}, fmt.Errorf("failed to parse MIME type '%s': expected type/subtype", mimeType)
+	}
+	return MIMEType{params: params, subtype: subtype, typ: typ}, nil
+}
+
+// MarshalJSON marshals the [MIMEType] object to JSON.
+func (m MIMEType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// MarshalText marshals the [MIMEType] object to plain text.
+func (m MIMEType) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// Matches returns whether or not the media type matches the given pattern, ignoring parameters.
+//
+// The pattern may be a full media type such as "image/png", a type wildcard such as "image/*" or "*/*". Matching is
+// not case sensitive.
+func (m MIMEType) Matches(pattern string) bool {
+	typ, subtype, found := strings.Cut(strings.ToLower(strings.TrimSpace(pattern)), "/")
+	if !found {
+		return false
+	}
+	if i := strings.IndexByte(subtype, ';'); i >= 0 {
+		subtype = strings.TrimSpace(subtype[:i])
+	}
+	return (typ == "*" || typ == m.typ) && (subtype == "*" || subtype == m.subtype)
+}
+
+// MatchesAny returns whether or not the media type matches any of the given patterns.
+func (m MIMEType) MatchesAny(patterns ...string) bool {
+	for _, p := range patterns {
+		if m.Matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Param returns the value of the given parameter, such as "charset", or an empty string if it is not set.
+func (m MIMEType) Param(name string) string {
+	return m.params[strings.ToLower(name)]
+}
+
+// Params returns a copy of the parameters of the media type.
+func (m MIMEType) Params() map[string]string {
+	return maps.Clone(m.params)
+}
+
+// String returns the [MIMEType] object as a string, such as "text/html; charset=utf-8".
+func (m MIMEType) String() string {
+	if m.typ == "" {
+		return ""
+	}
+	return mime.FormatMediaType(m.typ+"/"+m.subtype, m.params)
+}
+
+// Subtype returns the subtype of the media type, such as "html" for "text/html".
+func (m MIMEType) Subtype() string {
+	return m.subtype
+}
+
+// Type returns the top-level type of the media type, such as "text" for "text/html".
+func (m MIMEType) Type() string {
+	return m.typ
+}
+
+// UnmarshalJSON parses the JSON data into a [MIMEType] object.
+func (m *MIMEType) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return m.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [MIMEType] object.
+func (m *MIMEType) UnmarshalText(data []byte) error {
+	mimeType, err := ParseMIMEType(string(data))
+	if err != nil {
+		return err
+	}
+	*m = mimeType
+	return nil
+}
@@ -0,0 +1,35 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestMIMEType1(t *testing.T) {
+	m, err := types.ParseMIMEType("Text/HTML; Charset=UTF-8")
+	if err != nil {
+		t.Fatalf("failed to parse MIME type: %v", err)
+	}
+	if m.Type() != "text" || m.Subtype() != "html" || m.Param("charset") != "UTF-8" {
+		t.Errorf("unexpected MIME type parts: %s", m)
+	}
+	if !m.Matches("text/*") || !m.Matches("*/*") || !m.Matches("TEXT/html") || m.Matches("image/*") {
+		t.Errorf("unexpected pattern matching results for %s", m)
+	}
+	for _, str := range []string{"text", "/html", "text/", "text/html; charset"} {
+		if _, err := types.ParseMIMEType(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+
+	var filter struct {
+		Allowed []types.MIMEType `json:"allowed"`
+	}
+	if err := json.Unmarshal([]byte(`{"allowed": ["image/png", "application/pdf"]}`), &filter); err != nil {
+		t.Fatalf("failed to unmarshal MIME types: %v", err)
+	}
+	out, _ := json.Marshal(filter)
+	t.Logf("filter: %s", out)
+}
@@ -8,8 +8,56 @@ import (
 )
 
 // FileMode represents a file or directory mode.
+//
+// The mode uses the traditional Unix layout, so the setuid, setgid and sticky bits are stored as 04000, 02000 and
+// 01000 rather than in the high bits used by [os.FileMode].
 type FileMode int
 
+const (
+	// FileModeSetuid is the setuid bit.
+	FileModeSetuid FileMode = 04000
+
+	// FileModeSetgid is the setgid bit.
+	FileModeSetgid FileMode = 02000
+
+	// FileModeSticky is the sticky bit.
+	FileModeSticky FileMode = 01000
+
+	// fileModePerm is the mask for the permission bits of a [FileMode] object.
+	fileModePerm FileMode = 0777
+)
+
+// FileModeOf returns the [FileMode] equivalent of the given [os.FileMode], including the setuid, setgid and sticky
+// bits. The file type bits are discarded.
+func FileModeOf(mode os.FileMode) FileMode {
+	m := FileMode(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		m |= FileModeSetuid
+	}
+	if mode&os.ModeSetgid != 0 {
+		m |= FileModeSetgid
+	}
+	if mode&os.ModeSticky != 0 {
+		m |= FileModeSticky
+	}
+	return m
+}
+
+// HasSetgid returns whether or not the setgid bit is set.
+func (m FileMode) HasSetgid() bool {
+	return m&FileModeSetgid != 0
+}
+
+// HasSetuid returns whether or not the setuid bit is set.
+func (m FileMode) HasSetuid() bool {
+	return m&FileModeSetuid != 0
+}
+
+// HasSticky returns whether or not the sticky bit is set.
+func (m FileMode) HasSticky() bool {
+	return m&FileModeSticky != 0
+}
+
 // MarshalJSON marshals the [FileMode] object to JSON.
 func (m FileMode) MarshalJSON() ([]byte, error) {
 	return json.Marshal(fmt.Sprintf("%#o", m))
@@ -21,8 +69,40 @@ func (m FileMode) MarshalText() ([]byte, error) {
 }
 
 // OSFileMode returns the [os.FileMode] equivalent of the object.
+//
+// The setuid, setgid and sticky bits are translated to [os.ModeSetuid], [os.ModeSetgid] and [os.ModeSticky].
 func (m FileMode) OSFileMode() os.FileMode {
-	return os.FileMode(m)
+	mode := os.FileMode(m & fileModePerm)
+	if m.HasSetuid() {
+		mode |= os.ModeSetuid
+	}
+	if m.HasSetgid() {
+		mode |= os.ModeSetgid
+	}
+	if m.HasSticky() {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
+// Perm returns the permission bits of the mode without the setuid, setgid and sticky bits.
+func (m FileMode) Perm() FileMode {
+	return m & fileModePerm
+}
+
+// SetSetgid returns a copy of the mode with the setgid bit set or cleared.
+func (m FileMode) SetSetgid(on bool) FileMode {
+	return m.setBit(FileModeSetgid, on)
+}
+
+// SetSetuid returns a copy of the mode with the setuid bit set or cleared.
+func (m FileMode) SetSetuid(on bool) FileMode {
+	return m.setBit(FileModeSetuid, on)
+}
+
+// SetSticky returns a copy of the mode with the sticky bit set or cleared.
+func (m FileMode) SetSticky(on bool) FileMode {
+	return m.setBit(FileModeSticky, on)
 }
 
 // String returns the [FileMode] object as a string.
@@ -49,3 +129,11 @@ func (m *FileMode) UnmarshalText(data []byte) error {
 	*m = FileMode(mode)
 	return nil
 }
+
+// setBit returns a copy of the mode with the given bit set or cleared.
+func (m FileMode) setBit(bit FileMode, on bool) FileMode {
+	if on {
+		return m | bit
+	}
+	return m &^ bit
+}
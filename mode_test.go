@@ -0,0 +1,27 @@
+package types_test
+
+import (
+	"os"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestFileMode1(t *testing.T) {
+	mode := types.FileMode(0755).SetSetuid(true).SetSticky(true)
+	if mode != 05755 || !mode.HasSetuid() || mode.HasSetgid() || !mode.HasSticky() {
+		t.Errorf("unexpected mode: %s", mode)
+	}
+	osMode := mode.OSFileMode()
+	if osMode != os.ModeSetuid|os.ModeSticky|0755 {
+		t.Errorf("unexpected os.FileMode: %s", osMode)
+	}
+	t.Logf("%s => %s", mode, osMode)
+
+	if back := types.FileModeOf(osMode | os.ModeDir); back != mode {
+		t.Errorf("expected %s but got %s", mode, back)
+	}
+	if mode = mode.SetSetuid(false).SetSetgid(true); mode != 03755 || mode.Perm() != 0755 {
+		t.Errorf("unexpected mode: %s", mode)
+	}
+}
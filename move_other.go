@@ -0,0 +1,11 @@
+//go:build !unix && !windows
+
+package types
+
+// isCrossDevice returns whether or not the error indicates that a rename failed because the source and destination
+// are on different devices.
+//
+// The current platform has no such error, so this always returns false.
+func isCrossDevice(_ error) bool {
+	return false
+}
@@ -0,0 +1,14 @@
+//go:build unix
+
+package types
+
+import (
+	"errors"
+	"syscall"
+)
+
+// isCrossDevice returns whether or not the error indicates that a rename failed because the source and destination
+// are on different devices.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, syscall.EXDEV)
+}
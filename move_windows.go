@@ -0,0 +1,17 @@
+//go:build windows
+
+package types
+
+import (
+	"errors"
+	"syscall"
+)
+
+// errorNotSameDevice is the ERROR_NOT_SAME_DEVICE error code.
+const errorNotSameDevice syscall.Errno = 17
+
+// isCrossDevice returns whether or not the error indicates that a rename failed because the source and destination
+// are on different volumes.
+func isCrossDevice(err error) bool {
+	return errors.Is(err, errorNotSameDevice)
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Nullable is a value which tracks whether it was present in JSON data and, if so, whether it was null.
+//
+// This allows PATCH-style updates to distinguish between a field which was left out (do not change), a field which
+// was set to null (clear the value) and a field which was set to a value, including a zero value. Unlike [Optional],
+// which treats null and absent the same way, Nullable has three states:
+//
+//   - unset: the field was not present ([Nullable.IsSet] returns false)
+//   - null: the field was present with a null value ([Nullable.IsSet] returns true, [Nullable.Valid] returns false)
+//   - valid: the field was present with a value ([Nullable.Valid] returns true)
+//
+// The zero value is unset. Both unset and null values are marshaled as null.
+type Nullable[T any] struct {
+	set   bool
+	valid bool
+	value T
+}
+
+// NewNullable returns a valid Nullable object holding the given value.
+func NewNullable[T any](value T) Nullable[T] {
+	return Nullable[T]{set: true, valid: true, value: value}
+}
+
+// Null returns a Nullable object which is set to null.
+func Null[T any]() Nullable[T] {
+	return Nullable[T]{set: true}
+}
+
+// Get returns the value and whether or not it is valid.
+func (n Nullable[T]) Get() (T, bool) {
+	return n.value, n.valid
+}
+
+// IsNull returns whether or not the value was explicitly set to null.
+func (n Nullable[T]) IsNull() bool {
+	return n.set && !n.valid
+}
+
+// IsSet returns whether or not the value was present, either as null or as a value.
+func (n Nullable[T]) IsSet() bool {
+	return n.set
+}
+
+// MarshalJSON marshals the [Nullable] object to JSON.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+	if !n.valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.value)
+}
+
+// String returns the value formatted as a string, "null" if it is null or "unset" if it is not set.
+func (n Nullable[T]) String() string {
+	switch {
+	case !n.set:
+		return "unset"
+	case !n.valid:
+		return "null"
+	}
+	return fmt.Sprintf("%v", n.value)
+}
+
+// UnmarshalJSON parses the JSON data into a [Nullable] object.
+//
+// This is only called for fields which are present in the JSON data, so the object is always marked as set.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = Null[T]()
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*n = NewNullable(value)
+	return nil
+}
+
+// Valid returns whether or not the object holds a value which is neither unset nor null.
+func (n Nullable[T]) Valid() bool {
+	return n.valid
+}
+
+// Value returns the value, or the zero value if it is unset or null.
+func (n Nullable[T]) Value() T {
+	return n.value
+}
@@ -0,0 +1,32 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestNullable1(t *testing.T) {
+	var patch struct {
+		Name    types.Nullable[string] `json:"name"`
+		Email   types.Nullable[string] `json:"email"`
+		Retries types.Nullable[int]    `json:"retries"`
+	}
+	if err := json.Unmarshal([]byte(`{"email": null, "retries": 0}`), &patch); err != nil {
+		t.Fatalf("failed to unmarshal patch: %v", err)
+	}
+	if patch.Name.IsSet() {
+		t.Errorf("expected missing name to be unset but got %s", patch.Name)
+	}
+	if !patch.Email.IsSet() || !patch.Email.IsNull() || patch.Email.Valid() {
+		t.Errorf("expected email to be null but got %s", patch.Email)
+	}
+	if v, ok := patch.Retries.Get(); !ok || v != 0 {
+		t.Errorf("expected retries to be set to zero but got %s", patch.Retries)
+	}
+	out, _ := json.Marshal(patch)
+	if string(out) != `{"name":null,"email":null,"retries":0}` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+}
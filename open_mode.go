@@ -0,0 +1,57 @@
+package types
+
+import "os"
+
+// OpenMode describes how a file should be opened by [Path.Open].
+type OpenMode int
+
+const (
+	// OpenRead opens an existing file for reading only.
+	OpenRead OpenMode = iota
+
+	// OpenWrite opens the file for writing only, creating it if it does not exist and truncating it if it does.
+	OpenWrite
+
+	// OpenAppend opens the file for writing only, creating it if it does not exist and appending to it if it does.
+	OpenAppend
+
+	// OpenCreateExclusive creates a new file for writing only, failing if the file already exists.
+	OpenCreateExclusive
+
+	// OpenReadWrite opens the file for reading and writing, creating it if it does not exist.
+	OpenReadWrite
+)
+
+// Flags returns the [os.OpenFile] flags which correspond to the [OpenMode] object.
+func (m OpenMode) Flags() int {
+	switch m {
+	case OpenWrite:
+		return os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	case OpenAppend:
+		return os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	case OpenCreateExclusive:
+		return os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	case OpenReadWrite:
+		return os.O_RDWR | os.O_CREATE
+	default:
+		return os.O_RDONLY
+	}
+}
+
+// String returns the [OpenMode] object as a string.
+func (m OpenMode) String() string {
+	switch m {
+	case OpenRead:
+		return "read"
+	case OpenWrite:
+		return "write"
+	case OpenAppend:
+		return "append"
+	case OpenCreateExclusive:
+		return "create_exclusive"
+	case OpenReadWrite:
+		return "read_write"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Optional is a value which may or may not be present.
+//
+// Unlike a pointer, it distinguishes between a value which is absent and one which is set to its zero value without
+// any heap allocation. When unmarshaling JSON, a missing field or a null value leaves the object absent while any
+// other value, including a zero value, makes it present. Absent values are marshaled as null.
+//
+// The zero value is absent.
+type Optional[T any] struct {
+	present bool
+	value   T
+}
+
+// None returns an absent Optional object.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Some returns a present Optional object holding the given value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{present: true, value: value}
+}
+
+// Get returns the value and whether or not it is present.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.present
+}
+
+// IsPresent returns whether or not the value is present.
+func (o Optional[T]) IsPresent() bool {
+	return o.present
+}
+
+// MarshalJSON marshals the [Optional] object to JSON.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.present {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// OrElse returns the value if it is present or the given fallback value otherwise.
+func (o Optional[T]) OrElse(fallback T) T {
+	if !o.present {
+		return fallback
+	}
+	return o.value
+}
+
+// String returns the value formatted as a string or "none" if it is absent.
+func (o Optional[T]) String() string {
+	if !o.present {
+		return "none"
+	}
+	return fmt.Sprintf("%v", o.value)
+}
+
+// UnmarshalJSON parses the JSON data into an [Optional] object.
+//
+// A JSON null makes the object absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Optional[T]{}
+		return nil
+	}
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
@@ -0,0 +1,33 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestOptional1(t *testing.T) {
+	var config struct {
+		Retries types.Optional[int]    `json:"retries"`
+		Timeout types.Optional[int]    `json:"timeout"`
+		Name    types.Optional[string] `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(`{"retries": 0, "name": null}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal optional values: %v", err)
+	}
+	if v, ok := config.Retries.Get(); !ok || v != 0 {
+		t.Errorf("expected explicit zero to be present but got %s", config.Retries)
+	}
+	if config.Timeout.IsPresent() || config.Name.IsPresent() {
+		t.Errorf("expected missing and null values to be absent")
+	}
+	if config.Timeout.OrElse(30) != 30 || types.Some(5).OrElse(30) != 5 {
+		t.Errorf("unexpected fallback values")
+	}
+	out, _ := json.Marshal(config)
+	if string(out) != `{"retries":0,"timeout":null,"name":null}` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+	t.Logf("none: %s", types.None[int]())
+}
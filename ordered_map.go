@@ -0,0 +1,203 @@
+package types
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"iter"
+)
+
+// OrderedMap is a generic map which remembers the order in which keys were first inserted.
+//
+// Iteration and JSON marshaling both follow insertion order, so objects which are unmarshaled and marshaled again
+// keep their original key order. Keys must marshal to a JSON string or number, as is the case for strings, integers
+// and types implementing [encoding.TextMarshaler].
+//
+// The zero value is an empty map ready to use. OrderedMap objects are not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*list.Element
+	order   *list.List
+}
+
+// orderedMapEntry is a single key/value pair stored in an [OrderedMap] object.
+type orderedMapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewOrderedMap creates a new, empty OrderedMap object.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{}
+	m.init()
+	return m
+}
+
+// All returns an iterator over the key/value pairs in the map in insertion order.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.order == nil {
+			return
+		}
+		for e := m.order.Front(); e != nil; e = e.Next() {
+			entry := e.Value.(*orderedMapEntry[K, V])
+			if !yield(entry.key, entry.value) {
+				return
+			}
+		}
+	}
+}
+
+// Delete removes the given key from the map and returns whether or not it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	e, exists := m.entries[key]
+	if exists {
+		m.order.Remove(e)
+		delete(m.entries, key)
+	}
+	return exists
+}
+
+// Get returns the value stored for the given key and whether or not the key was present.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if e, exists := m.entries[key]; exists {
+		return e.Value.(*orderedMapEntry[K, V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Keys returns an iterator over the keys in the map in insertion order.
+func (m *OrderedMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of entries in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// MarshalJSON marshals the [OrderedMap] object to a JSON object with keys in insertion order.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	i := 0
+	for k, v := range m.All() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		i++
+
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		if len(key) == 0 || key[0] != '"' {
+			// numeric keys are quoted in the same way encoding/json handles integer map keys
+			if key, err = json.Marshal(string(key)); err != nil {
+				return nil, err
+			}
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		val, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Set stores the value for the given key.
+//
+// If the key already exists, its value is replaced but its position in the map is unchanged.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	if e, exists := m.entries[key]; exists {
+		e.Value.(*orderedMapEntry[K, V]).value = value
+		return
+	}
+	m.init()
+	m.entries[key] = m.order.PushBack(&orderedMapEntry[K, V]{key: key, value: value})
+}
+
+// UnmarshalJSON parses the JSON object into the [OrderedMap] object, preserving the order of its keys.
+//
+// Any existing entries in the map are replaced.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("failed to unmarshal ordered map: expected a JSON object")
+	}
+
+	m.entries, m.order = nil, nil
+	m.init()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := unmarshalOrderedMapKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+		var value V
+		if err := dec.Decode(&value); err != nil {
+			return fmt.Errorf("failed to unmarshal value for key '%s': %w", tok, err)
+		}
+		m.Set(key, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Values returns an iterator over the values in the map in insertion order.
+func (m *OrderedMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// init allocates the internal storage for the map if it has not been allocated yet.
+func (m *OrderedMap[K, V]) init() {
+	if m.entries == nil {
+		m.entries = map[K]*list.Element{}
+		m.order = list.New()
+	}
+}
+
+// unmarshalOrderedMapKey converts a JSON object key into a map key, first as a JSON string and then as a number.
+func unmarshalOrderedMapKey[K comparable](str string) (K, error) {
+	var key K
+	quoted, err := json.Marshal(str)
+	if err != nil {
+		return key, err
+	}
+	if err := json.Unmarshal(quoted, &key); err == nil {
+		return key, nil
+	}
+	if err := json.Unmarshal([]byte(str), &key); err != nil {
+		return key, fmt.Errorf("failed to unmarshal key '%s': %w", str, err)
+	}
+	return key, nil
+}
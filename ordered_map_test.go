@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestOrderedMap1(t *testing.T) {
+	var config struct {
+		Stages types.OrderedMap[string, int] `json:"stages"`
+	}
+	data := `{"stages":{"build":3,"test":1,"deploy":2}}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("failed to unmarshal ordered map: %v", err)
+	}
+	if keys := slices.Collect(config.Stages.Keys()); !slices.Equal(keys, []string{"build", "test", "deploy"}) {
+		t.Errorf("unexpected key order: %v", keys)
+	}
+	config.Stages.Set("test", 5)
+	config.Stages.Delete("build")
+	config.Stages.Set("build", 4)
+	out, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("failed to marshal ordered map: %v", err)
+	}
+	if string(out) != `{"stages":{"test":5,"deploy":2,"build":4}}` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+
+	ports := types.NewOrderedMap[int, string]()
+	ports.Set(443, "https")
+	ports.Set(80, "http")
+	out, _ = json.Marshal(ports)
+	var decoded types.OrderedMap[int, string]
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal integer keys: %v", err)
+	}
+	if v, ok := decoded.Get(443); !ok || v != "https" || decoded.Len() != 2 {
+		t.Errorf("unexpected decoded map: %s", out)
+	}
+}
@@ -1,14 +1,47 @@
 package types
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"os/user"
 	"path"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"go.innotegrity.dev/xerrors"
 )
 
+const (
+	// copyBufferSize is the size of the buffer used when copying file contents.
+	copyBufferSize = 32 * 1024
+
+	// defaultDirMode is the default mode used for directories by [NewPath].
+	defaultDirMode = FileMode(0755)
+
+	// defaultFileMode is the default mode used for files by [NewPath].
+	defaultFileMode = FileMode(0644)
+)
+
+var (
+	// errChownSkipped is returned by chown when ownership cannot be changed by the current user.
+	errChownSkipped = errors.New("only the root user may change ownership")
+
+	// errOSFSOnly is returned by functions which can only be performed on the operating system's filesystem.
+	errOSFSOnly = fmt.Errorf("%w on filesystems other than the operating system's", errors.ErrUnsupported)
+)
+
 // Path holds settings for a particular file or folder.
 type Path struct {
 	// AutoChmod indicates if the permissions of the file or directory should be changed when creating or opening it.
@@ -21,12 +54,32 @@ type Path struct {
 	// a file.
 	AutoCreateParent bool `json:"auto_create_parent" yaml:"auto_create_parent" mapstructure:"auto_create_parent"`
 
+	// AutoExpand indicates if a leading "~" or "~user" and any environment variables in [Path.FSPath] should be
+	// expanded before performing any operation on the path. See [Path.Expand] for details.
+	AutoExpand bool `json:"auto_expand" yaml:"auto_expand" mapstructure:"auto_expand"`
+
+	// Compression is the format used to transparently compress data written to the file and decompress data read
+	// from it. If empty, data is read and written as-is. See [Path.Copy] for how files are converted when copying
+	// between paths using different formats.
+	Compression Compression `json:"compression" yaml:"compression" mapstructure:"compression"`
+
 	// DirMode is the mode that should be used when creating the directory or any parent directories.
 	DirMode FileMode `json:"dir_mode" yaml:"dir_mode" mapstructure:"dir_mode"`
 
 	// FileMode is the mode that should be used when creating the file.
 	FileMode FileMode `json:"file_mode" yaml:"file_mode" mapstructure:"file_mode"`
 
+	// FollowSymlinks indicates if [Path.Chmod] and [Path.Chown] should operate on the target of a symbolic link
+	// rather than the link itself.
+	FollowSymlinks bool `json:"follow_symlinks" yaml:"follow_symlinks" mapstructure:"follow_symlinks"`
+
+	// FS is the filesystem on which all operations are performed. If nil, [OSFS] is used.
+	//
+	// [Path.DiskFree], [Path.DiskTotal], [Path.OpenFile] and [Path.ResolveSymlinks] fail if any other filesystem is
+	// set. Comparing [Path] objects using == panics if both have a filesystem of the same uncomparable type, such as
+	// an implementation based on a map, so use [Path.Same] or compare [Path.FSPath] values instead.
+	FS FS `json:"-" yaml:"-" mapstructure:"-"`
+
 	// FSPath is the path to the file or directory on the filesystem.
 	FSPath string `json:"path" yaml:"path" mapstructure:"path"`
 
@@ -35,14 +88,68 @@ type Path struct {
 
 	// Owner is the user name or ID that should own the file or directory.
 	Owner UserID `json:"owner" yaml:"owner" mapstructure:"owner"`
+
+	// StrictOwnership indicates if [Path.Chown] should return a [PathChownSkippedError] rather than silently doing
+	// nothing when the ownership cannot be changed because the current user is not root.
+	StrictOwnership bool `json:"strict_ownership" yaml:"strict_ownership" mapstructure:"strict_ownership"`
+
+	// WindowsACL indicates if [Path.Chmod] should replace the access control list of the file or directory on Windows
+	// with entries granting the owner, the group and everyone else the access described by its mode.
+	//
+	// This removes any entries inherited from the parent folder, including those granting access to SYSTEM and the
+	// Administrators group. If false, only the read-only attribute is changed on Windows.
+	WindowsACL bool `json:"windows_acl" yaml:"windows_acl" mapstructure:"windows_acl"`
+
+	// WindowsGroup is the account name or SID of the group that should own the file or directory on Windows.
+	WindowsGroup string `json:"windows_group" yaml:"windows_group" mapstructure:"windows_group"`
+
+	// WindowsOwner is the account name or SID of the user that should own the file or directory on Windows.
+	WindowsOwner string `json:"windows_owner" yaml:"windows_owner" mapstructure:"windows_owner"`
+}
+
+// RecursiveOptions holds the settings used when applying an operation to an entire directory tree.
+type RecursiveOptions struct {
+	// Concurrency is the number of files and folders which are processed at the same time. If less than 1, they are
+	// processed one at a time.
+	Concurrency int `json:"concurrency" yaml:"concurrency" mapstructure:"concurrency"`
+
+	// ContinueOnError indicates if processing should continue after an error occurs. When true, all of the errors
+	// which occurred are combined and returned together. Otherwise processing stops at the first error.
+	ContinueOnError bool `json:"continue_on_error" yaml:"continue_on_error" mapstructure:"continue_on_error"`
+}
+
+// NewPath creates a new [Path] object for the given filesystem path with sensible defaults.
+//
+// Directories are created with mode 0755 and files with mode 0644, parent folders are created automatically and
+// ownership is set to the current user and group.
+func NewPath(fsPath string) Path {
+	return Path{
+		AutoCreateParent: true,
+		DirMode:          defaultDirMode,
+		FileMode:         defaultFileMode,
+		FSPath:           fsPath,
+		Group:            GroupID(os.Getgid()),
+		Owner:            UserID(os.Getuid()),
+	}
 }
 
 // Abs attempts to convert the filesystem path to an absolute path.
 //
+// If [Path.AutoExpand] is true, the path is expanded first.
+//
 // This function may return an error with any of the following codes:
 //   - [PathError]: there was a general error while working with the path
 func (p *Path) Abs() xerrors.Error {
-	path, err := filepath.Abs(p.FSPath)
+	path := p.FSPath
+	if p.AutoExpand {
+		expanded, err := expandPath(path)
+		if err != nil {
+			return xerrors.Wrapf(PathError, err, "failed to expand '%s': %s", p.FSPath, err.Error()).
+				WithAttr("path", p.FSPath)
+		}
+		path = expanded
+	}
+	path, err := filepath.Abs(path)
 	if err != nil {
 		return xerrors.Wrapf(PathError, err, "failed to convert '%s' to an absolute path: %s", p.FSPath, err.Error()).
 			WithAttrs(map[string]any{
@@ -53,6 +160,60 @@ func (p *Path) Abs() xerrors.Error {
 	return nil
 }
 
+// Allocate ensures that disk space is reserved for the file up to the given size, creating the file if it does not
+// exist.
+//
+// On Linux, fallocate(2) is used so that the space is actually reserved on disk. On other platforms, or if the
+// filesystem does not support it, the file is extended to the given size instead, which may result in a sparse file.
+// The file is never shrunk; use [Path.Truncate] for that.
+//
+// The file is created using [Path.OpenFS], so the [Path.AutoCreateParent], [Path.AutoChmod] and [Path.AutoChown]
+// settings are honored. [Path.Compression] is ignored.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathTruncateError]: there was an error while changing the size of the file
+func (p Path) Allocate(size Size) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	if size < 0 {
+		err := fmt.Errorf("invalid size %s", size)
+		return xerrors.Wrapf(PathTruncateError, err, "failed to allocate space for '%s': %s", p.FSPath,
+			err.Error()).WithAttr("file", p.FSPath)
+	}
+	p.Compression = CompressionNone
+	file, xerr := p.OpenFS(os.O_CREATE | os.O_WRONLY)
+	if xerr != nil {
+		return xerr
+	}
+	defer file.Close()
+
+	// reserve the space if possible, otherwise fallback to extending the file
+	err := errors.ErrUnsupported
+	if osFile, ok := file.(*os.File); ok {
+		err = allocateFile(osFile, int64(size))
+	}
+	if err != nil {
+		var info fs.FileInfo
+		if info, err = file.Stat(); err == nil && info.Size() < int64(size) {
+			err = p.fs().Truncate(p.FSPath, int64(size))
+		}
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathTruncateError, err, "failed to allocate space for '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"file": p.FSPath,
+			"size": int64(size),
+		})
+	}
+	return nil
+}
+
 // Attrs returns the attributes of the path which can be attached to errors or log messages.
 func (p Path) Attrs() map[string]any {
 	return map[string]any{
@@ -64,24 +225,148 @@ func (p Path) Attrs() map[string]any {
 	}
 }
 
+// CheckAccess checks whether the current process may access the path in the given way.
+//
+// On Linux and MacOS, access(2) is used so that the check reflects the permissions, ownership and ACLs of the path as
+// well as read-only mounts. On Windows, the check is an approximation based on the file's attributes and extension.
+// On any other [FS], the owner permission bits of the path are checked.
+//
+// This is intended for validating paths before starting long-running work, since the result may change at any time.
+//
+// This function may return an error with any of the following codes:
+//   - [PathAccessDeniedError]: the current process may not access the path in the given way
+//   - [PathError]: there was a general error while working with the path
+func (p Path) CheckAccess(mode Access) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	var err error
+	fsys := p.fs()
+	if isOSFS(fsys) {
+		err = access(p.FSPath, mode)
+	} else if info, serr := fsys.Stat(p.FSPath); serr != nil {
+		err = serr
+	} else if _, ok := fsys.(*readOnlyFS); (ok && mode&AccessWrite != 0) || !mode.permits(info.Mode().Perm()>>6) {
+		err = fs.ErrPermission
+	}
+	return p.accessError(err, mode, "current process")
+}
+
+// CheckOwnerAccess checks whether the configured [Path.Owner] and [Path.Group] may access the path in the given way.
+//
+// The check is performed by evaluating the permission bits of the path against its owner and group in the same way
+// as the operating system would, so it does not take ACLs, supplementary groups or read-only mounts into account. If
+// [Path.Owner] is root, read and write access are always granted and execute access is granted if any execute bit is
+// set. On platforms where the ownership of a file cannot be determined, the owner permission bits are checked.
+//
+// This function may return an error with any of the following codes:
+//   - [PathAccessDeniedError]: the owner and group may not access the path in the given way
+//   - [PathError]: there was a general error while working with the path
+func (p Path) CheckOwnerAccess(mode Access) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	info, err := p.fs().Stat(p.FSPath)
+	if err != nil {
+		return p.accessError(err, mode, p.Owner.String())
+	}
+	perm := info.Mode().Perm()
+	uid, gid, ok := fileOwner(info)
+	allowed := false
+	switch {
+	case !ok:
+		allowed = mode.permits(perm >> 6)
+	case p.Owner == 0:
+		allowed = mode&AccessExecute == 0 || perm&0111 != 0 || info.IsDir()
+	case int(p.Owner) == uid:
+		allowed = mode.permits(perm >> 6)
+	case int(p.Group) == gid:
+		allowed = mode.permits(perm >> 3)
+	default:
+		allowed = mode.permits(perm)
+	}
+	if !allowed {
+		err = fs.ErrPermission
+	}
+	return p.accessError(err, mode, p.Owner.String())
+}
+
+// Checksum computes the checksum of the file's contents using the given algorithm.
+//
+// The file is read in chunks so large files do not need to fit in memory.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChecksumError]: the algorithm is not supported or there was an error while reading the file
+//   - [PathOpenFileError]: there was an error while opening the file
+func (p Path) Checksum(algo ChecksumAlgorithm) ([]byte, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+	h, ok := algo.newHash()
+	if !ok {
+		err := fmt.Errorf("unsupported checksum algorithm '%s'", algo)
+		return nil, xerrors.Wrapf(PathChecksumError, err, "failed to compute checksum of '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path":      p.FSPath,
+			"algorithm": algo.String(),
+		})
+	}
+	file, err := p.fs().Open(p.FSPath)
+	if err != nil {
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	defer file.Close()
+	if _, err := io.CopyBuffer(h, file, make([]byte, copyBufferSize)); err != nil {
+		return nil, xerrors.Wrapf(PathChecksumError, err, "failed to compute checksum of '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path":      p.FSPath,
+			"algorithm": algo.String(),
+		})
+	}
+	return h.Sum(nil), nil
+}
+
 // Chmod sets the permissions on the path.
 //
+// On Windows, only the read-only attribute is changed unless [Path.WindowsACL] is true, in which case the path's
+// access control list is also replaced with entries granting the equivalent read, write and execute access to the
+// owner, the group and everyone else.
+//
+// If the path is a symbolic link and [Path.FollowSymlinks] is false, nothing is changed since the permissions of a
+// symbolic link itself are not used.
+//
 // This function may return an error with any of the following codes:
 //   - [PathChmodError]: there was an error while changing the permissions on the file/folder
 //   - [PathError]: there was a general error while working with the path
 func (p Path) Chmod() xerrors.Error {
-	s, err := os.Stat(p.FSPath)
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	fsys := p.fs()
+	stat := fsys.Stat
+	if !p.FollowSymlinks {
+		stat = fsys.Lstat
+	}
+	s, err := stat(p.FSPath)
 	if err != nil {
 		return xerrors.Wrapf(PathError, err, "failed to change permissions of '%s': %s", p.FSPath, err.Error()).
 			WithAttrs(map[string]any{
 				"path": p.FSPath,
 			})
 	}
+	if s.Mode()&os.ModeSymlink != 0 {
+		return nil
+	}
 	mode := p.FileMode
 	if s.IsDir() {
 		mode = p.DirMode
 	}
-	if err := os.Chmod(p.FSPath, mode.OSFileMode()); err != nil {
+	err = fsys.Chmod(p.FSPath, mode.OSFileMode())
+	if err == nil && p.WindowsACL && isOSFS(fsys) {
+		err = setACL(p.FSPath, mode.OSFileMode())
+	}
+	if err != nil {
 		return xerrors.Wrapf(PathChmodError, err, "failed to change permissions of '%s': %s", p.FSPath, err.Error()).
 			WithAttrs(map[string]any{
 				"path":     p.FSPath,
@@ -91,17 +376,44 @@ func (p Path) Chmod() xerrors.Error {
 	return nil
 }
 
+// ChmodAll sets the permissions on the path and, if it is a directory, on everything within it.
+//
+// Directories are given the [Path.DirMode] permissions and files are given the [Path.FileMode] permissions. Symbolic
+// links within the tree are not followed, even if [Path.FollowSymlinks] is true.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on one or more files/folders
+//   - [PathError]: there was a general error while working with the path
+func (p Path) ChmodAll(opts RecursiveOptions) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	return p.applyAll(opts, PathChmodError, "change permissions of", Path.Chmod)
+}
+
 // Chown sets the ownership for the path.
 //
+// On Linux and MacOS, ownership is set to the [Path.Owner] and [Path.Group] values. Only the root user may change
+// ownership, so nothing is changed when running as any other user unless [Path.StrictOwnership] is true, in which
+// case an error is returned instead. If the path is a symbolic link and
+// [Path.FollowSymlinks] is false, the ownership of the link itself is changed rather than that of its target.
+//
+// On Windows, ownership is set to the [Path.WindowsOwner] and [Path.WindowsGroup] values instead. Nothing is changed
+// if neither value is set.
+//
 // This function may return an error with any of the following codes:
 //   - [PathChownError]: there was an error while changing ownership of the file/folder
+//   - [PathChownSkippedError]: [Path.StrictOwnership] is true and the current user may not change ownership
 func (p Path) Chown() xerrors.Error {
-	// only works for root
-	if os.Geteuid() != 0 {
-		return nil
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
 	}
-	if err := os.Chown(p.FSPath, int(p.Owner), int(p.Group)); err != nil {
-		return xerrors.Wrapf(PathChownError, err, "failed to change ownership of '%s': %s", p.FSPath, err.Error()).
+	if err := p.chown(); err != nil {
+		code := PathChownError
+		if errors.Is(err, errChownSkipped) {
+			code = PathChownSkippedError
+		}
+		return xerrors.Wrapf(code, err, "failed to change ownership of '%s': %s", p.FSPath, err.Error()).
 			WithAttrs(map[string]any{
 				"path":      p.FSPath,
 				"new_owner": p.Owner.String(),
@@ -111,124 +423,1736 @@ func (p Path) Chown() xerrors.Error {
 	return nil
 }
 
-// MkdirAll creates the given path and any parent folders if they do not exist.
+// ChownAll sets the ownership of the path and, if it is a directory, of everything within it.
 //
-// If [Path.AutoChmod] is true, the permissions will be set to the [Path.DirMode] value.
-// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+// Symbolic links within the tree are not followed, even if [Path.FollowSymlinks] is true.
 //
 // This function may return an error with any of the following codes:
-//   - [PathChmodError]: there was an error while changing the permissions on the folder
-//   - [PathChownError]: there was an error while changing ownership of the folder
-//   - [PathCreateError]: there was an error while creating the folder
+//   - [PathChownError]: there was an error while changing ownership of one or more files/folders
 //   - [PathError]: there was a general error while working with the path
-func (p Path) MkdirAll() xerrors.Error {
-	// create the folder
-	if err := os.MkdirAll(p.FSPath, p.DirMode.OSFileMode()); err != nil {
-		return xerrors.Wrapf(PathCreateError, err, "failed to create path '%s': %s", p.FSPath, err.Error()).
-			WithAttrs(map[string]any{
-				"path":     p.FSPath,
-				"dir_mode": fmt.Sprintf("%o", p.DirMode),
-			})
+func (p Path) ChownAll(opts RecursiveOptions) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
 	}
+	return p.applyAll(opts, PathChownError, "change ownership of", Path.Chown)
+}
 
-	// set ownership and permissions
-	if p.AutoChmod {
-		if xerr := p.Chmod(); xerr != nil {
-			return xerr
-		}
+// Clone creates a copy of the file at the given destination, sharing the underlying data blocks when possible.
+//
+// On filesystems which support it (such as Btrfs and XFS on Linux), the copy is created as a reflink so no data is
+// duplicated until either file is modified. Otherwise the contents are copied as described by [Path.Copy].
+//
+// The destination file is created or truncated using [Path.OpenFS], so the [Path.AutoCreateParent],
+// [Path.AutoChmod] and [Path.AutoChown] settings of dest are honored. If dest has no [Path.FileMode] set, the
+// permissions of the source file are preserved.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the destination file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the destination file/parent folder
+//   - [PathCopyError]: there was an error while copying the contents of the file or dest is the same file
+//   - [PathCreateError]: there was an error while creating the destination's parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while opening the source or destination file
+func (p Path) Clone(dest Path) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
 	}
-	if p.AutoChown {
-		if xerr := p.Chown(); xerr != nil {
+	src, xerr := p.openSource(&dest)
+	if xerr != nil {
+		return xerr
+	}
+	defer src.Close()
+
+	// try to create a reflink first; openSource has already ensured dest is not the source, so truncating it is safe
+	if srcFile, ok := src.(*os.File); ok && dest.Compression == CompressionNone {
+		out, xerr := dest.OpenFS(os.O_CREATE | os.O_WRONLY | os.O_TRUNC)
+		if xerr != nil {
 			return xerr
 		}
+		if outFile, ok := out.(*os.File); ok && cloneFile(srcFile, outFile) == nil {
+			if err := out.Close(); err != nil {
+				return xerrors.Wrapf(PathCopyError, err, "failed to clone '%s' to '%s': %s", p.FSPath,
+					dest.FSPath, err.Error()).WithAttrs(map[string]any{
+					"path": p.FSPath,
+					"dest": dest.FSPath,
+				})
+			}
+			return nil
+		}
+		out.Close()
 	}
-	return nil
+	return p.copyContents(src, dest, false)
 }
 
-// OpenFile creates/opens the file and returns its handle.
+// Copy copies the contents of the file to the given destination.
 //
-// If [Path.AutoCreateParent] is true, [Path.MkdirAll] will be called on the file's parent folder first.
-// If [Path.AutoChmod] is true, the permissions will be set to the [Path.DirMode] value.
-// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+// If both paths use the same [Path.Compression] format (including none), the raw bytes are copied unchanged.
+// Otherwise the contents are decompressed using the format of the source, if any, and compressed using the format of
+// the destination, if any.
+//
+// The destination file is created or truncated using [Path.OpenFS], so the [Path.AutoCreateParent],
+// [Path.AutoChmod] and [Path.AutoChown] settings of dest are honored. If dest has no [Path.FileMode] set, the
+// permissions of the source file are preserved.
 //
 // This function may return an error with any of the following codes:
-//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
-//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
-//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathChmodError]: there was an error while changing the permissions on the destination file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the destination file/parent folder
+//   - [PathCopyError]: there was an error while copying the contents of the file or dest is the same file
+//   - [PathCreateError]: there was an error while creating the destination's parent folder
 //   - [PathError]: there was a general error while working with the path
-//   - [PathOpenFileError]: there was an error while opening the file
-func (p Path) OpenFile(flags int) (*os.File, xerrors.Error) {
-	// create parent folder if desired
-	if p.AutoCreateParent {
-		parent := Path{
-			DirMode: p.DirMode,
-			Group:   p.Group,
-			Owner:   p.Owner,
-			FSPath:  path.Dir(p.FSPath),
-		}
-		if xerr := parent.MkdirAll(); xerr != nil {
-			return nil, xerrors.Wrapf(PathOpenFileError, xerr, "failed to open file '%s': %s", p.FSPath,
-				xerr.Error()).WithAttrs(map[string]any{
-				"file":      p.FSPath,
-				"file_mode": fmt.Sprintf("%o", p.FileMode),
-			})
-		}
+//   - [PathOpenFileError]: there was an error while opening the source or destination file
+func (p Path) Copy(dest Path) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	src, xerr := p.openSource(&dest)
+	if xerr != nil {
+		return xerr
 	}
+	defer src.Close()
+	return p.copyContents(src, dest, false)
+}
 
-	// open the file
-	file, err := os.OpenFile(p.FSPath, flags, p.FileMode.OSFileMode())
+// DiskFree returns the amount of space available to the current user on the filesystem containing the path.
+//
+// This function may return an error with any of the following codes:
+//   - [PathDiskUsageError]: there was an error while querying the filesystem or the path is not on the operating
+//     system's filesystem
+func (p Path) DiskFree() (Size, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return 0, xerr
+	}
+	if !isOSFS(p.fs()) {
+		return 0, xerrors.Wrapf(PathDiskUsageError, errOSFSOnly, "failed to get free space for '%s': %s", p.FSPath,
+			errOSFSOnly.Error()).WithAttr("path", p.FSPath)
+	}
+	free, _, err := diskSpace(p.FSPath)
 	if err != nil {
-		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
-			WithAttrs(map[string]any{
-				"file":      p.FSPath,
-				"file_mode": fmt.Sprintf("%o", p.FileMode),
-			})
+		return 0, xerrors.Wrapf(PathDiskUsageError, err, "failed to get free space for '%s': %s", p.FSPath,
+			err.Error()).WithAttr("path", p.FSPath)
 	}
+	return Size(free), nil
+}
 
-	// set ownership and permissions
-	if p.AutoChmod {
-		if xerr := p.Chmod(); xerr != nil {
-			file.Close()
-			return nil, xerr
-		}
+// DiskTotal returns the total size of the filesystem containing the path.
+//
+// This function may return an error with any of the following codes:
+//   - [PathDiskUsageError]: there was an error while querying the filesystem or the path is not on the operating
+//     system's filesystem
+func (p Path) DiskTotal() (Size, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return 0, xerr
 	}
-	if p.AutoChown {
-		if xerr := p.Chown(); xerr != nil {
-			file.Close()
-			return nil, xerr
-		}
+	if !isOSFS(p.fs()) {
+		return 0, xerrors.Wrapf(PathDiskUsageError, errOSFSOnly, "failed to get total space for '%s': %s", p.FSPath,
+			errOSFSOnly.Error()).WithAttr("path", p.FSPath)
+	}
+	_, total, err := diskSpace(p.FSPath)
+	if err != nil {
+		return 0, xerrors.Wrapf(PathDiskUsageError, err, "failed to get total space for '%s': %s", p.FSPath,
+			err.Error()).WithAttr("path", p.FSPath)
 	}
-	return file, nil
+	return Size(total), nil
 }
 
-// WriteFile writes the given data the file.
+// Expand expands a leading "~" or "~user" in [Path.FSPath] to the corresponding user's home directory and replaces
+// any "${VAR}" or "$VAR" references with the value of the environment variable.
 //
-// This function uses the [Path.OpenFile] function to create/open the file before writing to it. It automatically
-// closes the file after writing to it.
+// Undefined environment variables are replaced with an empty string.
 //
 // This function may return an error with any of the following codes:
-//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
-//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathError]: the home directory of the user could not be determined
+func (p *Path) Expand() xerrors.Error {
+	path, err := expandPath(p.FSPath)
+	if err != nil {
+		return xerrors.Wrapf(PathError, err, "failed to expand '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+	p.FSPath = path
+	return nil
+}
+
+// Glob returns the paths within the folder matching the given pattern.
+//
+// The pattern is relative to the folder and uses the syntax described by [filepath.Match]. Each returned [Path]
+// inherits all other settings from this path.
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: the pattern is malformed
+func (p Path) Glob(pattern string) ([]Path, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+	matches, err := globFS(p.fs(), filepath.Join(p.FSPath, pattern))
+	if err != nil {
+		return nil, xerrors.Wrapf(PathError, err, "failed to match '%s' in '%s': %s", pattern, p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path":    p.FSPath,
+			"pattern": pattern,
+		})
+	}
+	paths := make([]Path, 0, len(matches))
+	for _, m := range matches {
+		paths = append(paths, p.withFSPath(m))
+	}
+	return paths, nil
+}
+
+// IsExecutable returns whether or not the current process may execute the file or search the directory.
+//
+// See [Path.CheckAccess] for details.
+func (p Path) IsExecutable() bool {
+	return p.CheckAccess(AccessExecute) == nil
+}
+
+// IsReadable returns whether or not the current process may read the path.
+//
+// See [Path.CheckAccess] for details.
+func (p Path) IsReadable() bool {
+	return p.CheckAccess(AccessRead) == nil
+}
+
+// IsWritable returns whether or not the current process may write to the path.
+//
+// See [Path.CheckAccess] for details.
+func (p Path) IsWritable() bool {
+	return p.CheckAccess(AccessWrite) == nil
+}
+
+// Join returns a copy of the path with the given elements appended to [Path.FSPath].
+//
+// The joined elements must remain within the path, so any elements which would escape it using ".." or which are
+// absolute paths are rejected. This makes it safe to build paths from untrusted input. The returned [Path] inherits
+// all other settings from this path.
+//
+// This function may return an error with any of the following codes:
+//   - [PathTraversalError]: the elements would escape the path
+func (p Path) Join(elems ...string) (Path, xerrors.Error) {
+	rel := filepath.Join(elems...)
+	if rel != "" && !filepath.IsLocal(rel) {
+		err := fmt.Errorf("'%s' escapes '%s'", rel, p.FSPath)
+		return Path{}, xerrors.Wrapf(PathTraversalError, err, "failed to join path: %s", err.Error()).
+			WithAttrs(map[string]any{
+				"path":  p.FSPath,
+				"elems": elems,
+			})
+	}
+	return p.withFSPath(filepath.Join(p.FSPath, rel)), nil
+}
+
+// Link creates a hard link to the file at the given destination.
+//
+// Since both paths refer to the same file, the permissions and ownership of dest are not applied. If
+// dest.AutoCreateParent is true, the destination's parent folder will be created first.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the parent folder
+//   - [PathChownError]: there was an error while changing ownership of the parent folder
 //   - [PathCreateError]: there was an error while creating the parent folder
 //   - [PathError]: there was a general error while working with the path
-//   - [PathOpenFileError]: there was an error while opening the file
-//   - [PathWriteError]: there was an error while writing to the file
-func (p Path) WriteFile(data []byte, overwrite bool) xerrors.Error {
-	flags := os.O_CREATE | os.O_RDWR
-	if overwrite {
-		flags |= os.O_TRUNC
-	} else {
-		flags |= os.O_APPEND
+//   - [PathLinkError]: there was an error while creating the link
+func (p Path) Link(dest Path) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
 	}
-	handle, xerr := p.OpenFile(flags)
-	if xerr != nil {
+	if xerr := dest.autoExpand(); xerr != nil {
 		return xerr
 	}
-	defer handle.Close()
-
-	if _, err := handle.Write(data); err != nil {
-		return xerrors.Wrapf(PathWriteError, err, "failed to write to file '%s': %s", p.FSPath, err.Error()).
-			WithAttr("file", p.FSPath)
+	if xerr := dest.createParent(); xerr != nil {
+		return xerr
+	}
+	err := errors.New("paths are on different filesystems")
+	if sameFS(p.fs(), dest.fs()) {
+		err = p.fs().Link(p.FSPath, dest.FSPath)
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathLinkError, err, "failed to link '%s' to '%s': %s", dest.FSPath, p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"dest": dest.FSPath,
+		})
 	}
 	return nil
 }
+
+// MkdirAll creates the given path and any parent folders if they do not exist.
+//
+// If [Path.AutoChmod] is true, the permissions will be set to the [Path.DirMode] value.
+// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the folder
+//   - [PathChownError]: there was an error while changing ownership of the folder
+//   - [PathCreateError]: there was an error while creating the folder
+//   - [PathError]: there was a general error while working with the path
+func (p Path) MkdirAll() xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	// create the folder
+	if err := p.fs().MkdirAll(p.FSPath, p.DirMode.OSFileMode()); err != nil {
+		return xerrors.Wrapf(PathCreateError, err, "failed to create path '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"path":     p.FSPath,
+				"dir_mode": fmt.Sprintf("%o", p.DirMode),
+			})
+	}
+
+	// set ownership and permissions
+	return p.applyOwnership()
+}
+
+// Move moves the file or directory to the given destination.
+//
+// The path is renamed whenever possible. If the destination is on a different filesystem, the file is copied to the
+// destination, synced to disk and then removed from its original location instead. Directories are copied
+// recursively in the same way, including any symbolic links they contain, as long as the destination does not exist
+// yet; if copying fails, whatever was copied is removed from the destination. The file is always moved as-is, so
+// [Path.Compression] is ignored.
+//
+// If dest.AutoCreateParent is true, the destination's parent folder will be created first. Ownership and
+// permissions of the destination are applied according to its [Path.AutoChmod] and [Path.AutoChown] settings. If
+// dest has no [Path.FileMode] (or [Path.DirMode] for a directory) set, the permissions of the source are preserved.
+// The permissions of everything within a copied directory are always preserved.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the destination
+//   - [PathChownError]: there was an error while changing ownership of the destination
+//   - [PathCopyError]: there was an error while copying the file or directory to another filesystem
+//   - [PathCreateError]: there was an error while creating the destination's parent folder or a copied folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathMoveError]: there was an error while moving the file or directory
+//   - [PathMovePartialError]: the file or directory was copied to the destination but could not be removed from the
+//     source
+//   - [PathOpenFileError]: there was an error while opening the source or destination file
+//   - [PathSymlinkError]: there was an error while copying a symbolic link to another filesystem
+func (p Path) Move(dest Path) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	if xerr := dest.autoExpand(); xerr != nil {
+		return xerr
+	}
+	info, err := p.fs().Stat(p.FSPath)
+	if err != nil {
+		return xerrors.Wrapf(PathError, err, "failed to move '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+	if dest.FileMode == 0 && !info.IsDir() {
+		dest.FileMode = FileModeOf(info.Mode())
+	}
+	if xerr := dest.createParent(); xerr != nil {
+		return xerr
+	}
+
+	// try a simple rename first if both paths are on the same filesystem
+	crossDevice := true
+	if sameFS(p.fs(), dest.fs()) {
+		err = p.fs().Rename(p.FSPath, dest.FSPath)
+		if err == nil {
+			return dest.applyOwnership()
+		}
+		crossDevice = isCrossDevice(err)
+	} else {
+		err = errors.New("paths are on different filesystems")
+	}
+	if !crossDevice {
+		return xerrors.Wrapf(PathMoveError, err, "failed to move '%s' to '%s': %s", p.FSPath, dest.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"dest": dest.FSPath,
+		})
+	}
+	if info.IsDir() {
+		return p.moveDir(dest, info)
+	}
+
+	// fall back to copying the file across filesystems
+	src, err := p.fs().Open(p.FSPath)
+	if err != nil {
+		return xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	dest.Compression = CompressionNone
+	xerr := p.copyContents(src, dest, true)
+	src.Close()
+	if xerr != nil {
+		return xerr
+	}
+	if err := p.fs().Remove(p.FSPath); err != nil {
+		return xerrors.Wrapf(PathMovePartialError, err, "copied '%s' to '%s' but failed to remove the source: %s",
+			p.FSPath, dest.FSPath, err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"dest": dest.FSPath,
+		})
+	}
+	return nil
+}
+
+// Open opens the file using the given mode and returns its handle.
+//
+// This is a convenience wrapper around [Path.OpenFS] which uses the flags returned by [OpenMode.Flags], so the file is
+// opened using [Path.FS] and [Path.Compression] if they are set.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathExistsError]: the mode is [OpenCreateExclusive] and the file already exists
+//   - [PathOpenFileError]: there was an error while opening the file
+func (p Path) Open(mode OpenMode) (File, xerrors.Error) {
+	return p.OpenFS(mode.Flags())
+}
+
+// OpenFS creates/opens the file on the path's filesystem and returns its handle.
+//
+// Unlike [Path.OpenFile], the file is opened using [Path.FS] if it is set and a [File] is returned.
+//
+// If [Path.Compression] is set, data written to the handle is compressed and data read from it is decompressed. In
+// that case, the file must be opened either for reading or for writing, but not both, and the handle cannot be seeked.
+//
+// If [Path.AutoCreateParent] is true, [Path.MkdirAll] will be called on the file's parent folder first.
+// If [Path.AutoChmod] is true, the permissions will be set to the [Path.DirMode] value.
+// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathExistsError]: flags includes [os.O_CREATE] and [os.O_EXCL] and the file already exists
+//   - [PathOpenFileError]: there was an error while opening the file
+func (p Path) OpenFS(flags int) (File, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+	// create parent folder if desired
+	if xerr := p.createParent(); xerr != nil {
+		return nil, xerrors.Wrapf(PathOpenFileError, xerr, "failed to open file '%s': %s", p.FSPath,
+			xerr.Error()).WithAttrs(map[string]any{
+			"file":      p.FSPath,
+			"file_mode": fmt.Sprintf("%o", p.FileMode),
+		})
+	}
+
+	// open the file
+	file, err := p.fs().OpenFile(p.FSPath, flags, p.FileMode.OSFileMode())
+	if err != nil {
+		code := PathOpenFileError
+		if flags&os.O_EXCL != 0 && errors.Is(err, fs.ErrExist) {
+			code = PathExistsError
+		}
+		return nil, xerrors.Wrapf(code, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":      p.FSPath,
+				"file_mode": fmt.Sprintf("%o", p.FileMode),
+			})
+	}
+
+	// set ownership and permissions
+	if xerr := p.applyOwnership(); xerr != nil {
+		file.Close()
+		return nil, xerr
+	}
+
+	// wrap the file if it should be compressed
+	if p.Compression == CompressionNone {
+		return file, nil
+	}
+	cfile, err := p.Compression.wrap(file, flags)
+	if err != nil {
+		file.Close()
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":        p.FSPath,
+				"compression": p.Compression.String(),
+			})
+	}
+	return cfile, nil
+}
+
+// OpenFile creates/opens the file on the operating system's filesystem and returns its handle.
+//
+// Since an [*os.File] is returned, the path must not have a [Path.FS] other than [OSFS] or any [Path.Compression]
+// set. Use [Path.OpenFS] to open such files.
+//
+// If [Path.AutoCreateParent] is true, [Path.MkdirAll] will be called on the file's parent folder first.
+// If [Path.AutoChmod] is true, the permissions will be set to the [Path.DirMode] value.
+// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathExistsError]: flags includes [os.O_CREATE] and [os.O_EXCL] and the file already exists
+//   - [PathOpenFileError]: there was an error while opening the file or the path is not on the operating system's
+//     filesystem or uses compression
+func (p Path) OpenFile(flags int) (*os.File, xerrors.Error) {
+	if !isOSFS(p.fs()) || p.Compression != CompressionNone {
+		err := errors.New("the file is not on the operating system's filesystem or is compressed; use OpenFS instead")
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":        p.FSPath,
+				"compression": p.Compression.String(),
+			})
+	}
+	file, xerr := p.OpenFS(flags)
+	if xerr != nil {
+		return nil, xerr
+	}
+	return file.(*os.File), nil
+}
+
+// ReadFile reads the entire contents of the file.
+//
+// If [Path.Compression] is set, the contents are decompressed.
+//
+// This function may return an error with any of the following codes:
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathReadError]: there was an error while reading the file
+func (p Path) ReadFile() ([]byte, xerrors.Error) {
+	return p.ReadFileMax(0)
+}
+
+// ReadFileMax reads the entire contents of the file as long as it is no larger than the given limit.
+//
+// If limit is 0 or less, the file is read regardless of its size. If [Path.Compression] is set, the contents are
+// decompressed and the limit applies to the decompressed size.
+//
+// This function may return an error with any of the following codes:
+//   - [PathFileTooLargeError]: the file is larger than the limit
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathReadError]: there was an error while reading the file
+func (p Path) ReadFileMax(limit Size) ([]byte, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+	raw, err := p.fs().Open(p.FSPath)
+	if err != nil {
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	defer raw.Close()
+	file := raw
+	if p.Compression != CompressionNone {
+		if file, err = p.Compression.wrap(raw, os.O_RDONLY); err != nil {
+			return nil, xerrors.Wrapf(PathReadError, err, "failed to read file '%s': %s", p.FSPath, err.Error()).
+				WithAttrs(map[string]any{
+					"file":        p.FSPath,
+					"compression": p.Compression.String(),
+				})
+		}
+		defer file.Close()
+	}
+
+	// check the size up front to avoid reading anything we know is too large
+	var size int64
+	if info, err := file.Stat(); err == nil && p.Compression == CompressionNone {
+		size = info.Size()
+	}
+	maxSize := int64(limit)
+	if maxSize > 0 && size > maxSize {
+		err := fmt.Errorf("file size %s exceeds the limit of %s", Size(size), limit)
+		return nil, xerrors.Wrapf(PathFileTooLargeError, err, "failed to read file '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"file":  p.FSPath,
+			"size":  size,
+			"limit": maxSize,
+		})
+	}
+
+	// read the file, guarding against it growing past the limit while being read
+	var r io.Reader = file
+	if maxSize > 0 {
+		r = io.LimitReader(file, maxSize+1)
+	}
+	buf := bytes.NewBuffer(make([]byte, 0, size+bytes.MinRead))
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, xerrors.Wrapf(PathReadError, err, "failed to read file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	if maxSize > 0 && int64(buf.Len()) > maxSize {
+		err := fmt.Errorf("file size exceeds the limit of %s", limit)
+		return nil, xerrors.Wrapf(PathFileTooLargeError, err, "failed to read file '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"file":  p.FSPath,
+			"limit": maxSize,
+		})
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadJSON reads the file and decodes its JSON contents into the value pointed to by v.
+//
+// If [Path.Compression] is set, the contents are decompressed first.
+//
+// This function may return an error with any of the following codes:
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathReadError]: there was an error while reading or decoding the file
+func (p Path) ReadJSON(v any) xerrors.Error {
+	return p.readAndDecode(v, "JSON", json.Unmarshal)
+}
+
+// ReadYAML reads the file and decodes its YAML contents into the value pointed to by v.
+//
+// YAML is not supported by the standard library, so this function always fails unless a YAML library has been
+// registered with [RegisterYAML]. If [Path.Compression] is set, the contents are decompressed first.
+//
+// This function may return an error with any of the following codes:
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathReadError]: there was an error while reading or decoding the file
+//   - [PathYAMLNotRegisteredError]: no YAML library has been registered
+func (p Path) ReadYAML(v any) xerrors.Error {
+	if !yamlRegistered() {
+		return p.yamlNotRegistered()
+	}
+	return p.readAndDecode(v, "YAML", unmarshalYAML)
+}
+
+// Readlink returns the destination of the symbolic link.
+//
+// This function may return an error with any of the following codes:
+//   - [PathSymlinkError]: the path is not a symbolic link or there was an error while reading it
+func (p Path) Readlink() (string, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return "", xerr
+	}
+	target, err := p.fs().Readlink(p.FSPath)
+	if err != nil {
+		return "", xerrors.Wrapf(PathSymlinkError, err, "failed to read link '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+	return target, nil
+}
+
+// Remove removes the file or empty directory.
+//
+// This function may return an error with any of the following codes:
+//   - [PathDeleteError]: there was an error while removing the file or directory
+func (p Path) Remove() xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	if err := p.fs().Remove(p.FSPath); err != nil {
+		return xerrors.Wrapf(PathDeleteError, err, "failed to remove '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+	return nil
+}
+
+// RemoveAll removes the path and any children it contains.
+//
+// As a safeguard, the path must be located within the given root folder (or be the root folder itself). The
+// filesystem root and the current user's home directory are never removed.
+//
+// This function may return an error with any of the following codes:
+//   - [PathDeleteError]: the path is outside of the root or there was an error while removing it
+func (p Path) RemoveAll(root string) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	fsys := p.fs()
+	target, root := filepath.Clean(p.FSPath), filepath.Clean(root)
+	var err error
+	if isOSFS(fsys) {
+		target, err = resolvePath(target)
+		if err == nil {
+			root, err = filepath.Abs(root)
+		}
+		if err == nil {
+			if resolved, evalErr := filepath.EvalSymlinks(root); evalErr == nil {
+				root = resolved
+			}
+		}
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathDeleteError, err, "failed to remove '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"path": p.FSPath,
+				"root": root,
+			})
+	}
+
+	// guard against removing anything dangerous or outside of the root folder
+	if isProtectedPath(target) {
+		err = fmt.Errorf("refusing to remove protected path '%s'", target)
+	} else if rel, relErr := filepath.Rel(root, target); relErr != nil || !filepath.IsLocal(rel) {
+		err = fmt.Errorf("'%s' is not within '%s'", target, root)
+	}
+	if err == nil {
+		err = fsys.RemoveAll(target)
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathDeleteError, err, "failed to remove '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"path": p.FSPath,
+				"root": root,
+			})
+	}
+	return nil
+}
+
+// ResolveSymlinks returns a copy of the path with all symbolic links in [Path.FSPath] resolved.
+//
+// The returned [Path] inherits all other settings from this path.
+//
+// This function may return an error with any of the following codes:
+//   - [PathSymlinkError]: there was an error while resolving the symbolic links or the path is not on the operating
+//     system's filesystem
+func (p Path) ResolveSymlinks() (Path, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return Path{}, xerr
+	}
+	if !isOSFS(p.fs()) {
+		return Path{}, xerrors.Wrapf(PathSymlinkError, errOSFSOnly, "failed to resolve links in '%s': %s", p.FSPath,
+			errOSFSOnly.Error()).WithAttr("path", p.FSPath)
+	}
+	resolved, err := filepath.EvalSymlinks(p.FSPath)
+	if err != nil {
+		return Path{}, xerrors.Wrapf(PathSymlinkError, err, "failed to resolve links in '%s': %s", p.FSPath,
+			err.Error()).WithAttr("path", p.FSPath)
+	}
+	return p.withFSPath(resolved), nil
+}
+
+// Same returns whether or not the path and the given path refer to the same file or folder.
+//
+// Symbolic links are resolved and, on the operating system's filesystem, the device and inode (or the volume serial
+// number and file index on Windows) of each path are compared, so the result is reliable even when the paths are
+// spelled differently. On any other [FS], the cleaned paths are compared instead. Paths on different filesystems are
+// never the same.
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: there was a general error while working with either path
+func (p Path) Same(other Path) (bool, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return false, xerr
+	}
+	if xerr := other.autoExpand(); xerr != nil {
+		return false, xerr
+	}
+	if !sameFS(p.fs(), other.fs()) {
+		return false, nil
+	}
+	infos := make([]fs.FileInfo, 0, 2)
+	for _, fsPath := range []string{p.FSPath, other.FSPath} {
+		info, err := p.fs().Stat(fsPath)
+		if err != nil {
+			return false, xerrors.Wrapf(PathError, err, "failed to compare '%s' to '%s': %s", p.FSPath,
+				other.FSPath, err.Error()).WithAttrs(map[string]any{
+				"path":  p.FSPath,
+				"other": other.FSPath,
+			})
+		}
+		infos = append(infos, info)
+	}
+	if isOSFS(p.fs()) {
+		return os.SameFile(infos[0], infos[1]), nil
+	}
+	return toFSName(p.FSPath) == toFSName(other.FSPath), nil
+}
+
+// Symlink creates a symbolic link at the path which points to the given target.
+//
+// If [Path.AutoCreateParent] is true, [Path.MkdirAll] will be called on the link's parent folder first.
+// If [Path.AutoChown] is true, the ownership of the link itself will be set to the [Path.Owner] and [Path.Group]
+// values. The target is never changed, even if [Path.FollowSymlinks] is true, and [Path.AutoChmod] is ignored since
+// the permissions of a symbolic link are not used.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChownError]: there was an error while changing ownership of the link
+//   - [PathChownSkippedError]: [Path.StrictOwnership] is true and the current user may not change ownership
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathSymlinkError]: there was an error while creating the link
+func (p Path) Symlink(target string) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	if xerr := p.createParent(); xerr != nil {
+		return xerr
+	}
+	if err := p.fs().Symlink(target, p.FSPath); err != nil {
+		return xerrors.Wrapf(PathSymlinkError, err, "failed to create link '%s' to '%s': %s", p.FSPath, target,
+			err.Error()).WithAttrs(map[string]any{
+			"path":   p.FSPath,
+			"target": target,
+		})
+	}
+	if !p.AutoChown {
+		return nil
+	}
+	p.FollowSymlinks = false
+	return p.Chown()
+}
+
+// TempDir creates a new temporary directory within the folder and returns its path.
+//
+// The name of the directory is generated using the given pattern as described by [os.MkdirTemp]. The returned
+// [Path] inherits all other settings from this path and the new directory is given the [Path.DirMode] permissions.
+//
+// If [Path.AutoCreateParent] is true, [Path.MkdirAll] will be called on the folder first.
+// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the directory/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the directory/parent folder
+//   - [PathCreateError]: there was an error while creating the directory/parent folder
+//   - [PathError]: there was a general error while working with the path
+func (p Path) TempDir(pattern string) (Path, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return Path{}, xerr
+	}
+	if p.AutoCreateParent {
+		if xerr := p.MkdirAll(); xerr != nil {
+			return Path{}, xerr
+		}
+	}
+	name, err := mkdirTemp(p.fs(), p.FSPath, pattern)
+	if err != nil {
+		return Path{}, xerrors.Wrapf(PathCreateError, err, "failed to create temporary directory in '%s': %s",
+			p.FSPath, err.Error()).WithAttrs(map[string]any{
+			"path":    p.FSPath,
+			"pattern": pattern,
+		})
+	}
+	return p.finalizeTemp(name)
+}
+
+// TempFile creates a new, empty temporary file within the folder and returns its path.
+//
+// The name of the file is generated using the given pattern as described by [os.CreateTemp]. The returned [Path]
+// inherits all other settings from this path and the new file is given the [Path.FileMode] permissions.
+//
+// If [Path.AutoCreateParent] is true, [Path.MkdirAll] will be called on the folder first.
+// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the file/parent folder
+//   - [PathError]: there was a general error while working with the path
+func (p Path) TempFile(pattern string) (Path, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return Path{}, xerr
+	}
+	if p.AutoCreateParent {
+		if xerr := p.MkdirAll(); xerr != nil {
+			return Path{}, xerr
+		}
+	}
+	file, err := createTemp(p.fs(), p.FSPath, pattern, 0600)
+	if err != nil {
+		return Path{}, xerrors.Wrapf(PathCreateError, err, "failed to create temporary file in '%s': %s",
+			p.FSPath, err.Error()).WithAttrs(map[string]any{
+			"path":    p.FSPath,
+			"pattern": pattern,
+		})
+	}
+	file.Close()
+	return p.finalizeTemp(file.Name())
+}
+
+// Touch creates the file if it does not exist or updates its access and modification times to the current time if it
+// does, similar to "touch".
+//
+// See [Path.TouchAt] for details.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while creating the file
+//   - [PathTimesError]: there was an error while changing the access and modification times of the file
+func (p Path) Touch() xerrors.Error {
+	return p.TouchAt(time.Now())
+}
+
+// TouchAt creates the file if it does not exist and sets its access and modification times to the given time.
+//
+// The file is created empty using [Path.OpenFS], so the [Path.AutoCreateParent], [Path.AutoChmod] and
+// [Path.AutoChown] settings are honored. [Path.Compression] is ignored so that the new file is truly empty.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while creating the file
+//   - [PathTimesError]: there was an error while changing the access and modification times of the file
+func (p Path) TouchAt(t time.Time) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	if _, err := p.fs().Stat(p.FSPath); errors.Is(err, fs.ErrNotExist) {
+		p.Compression = CompressionNone
+		file, xerr := p.OpenFS(os.O_CREATE | os.O_WRONLY)
+		if xerr != nil {
+			return xerr
+		}
+		file.Close()
+	}
+	if err := p.fs().Chtimes(p.FSPath, t, t); err != nil {
+		return xerrors.Wrapf(PathTimesError, err, "failed to change times of '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"path": p.FSPath,
+				"time": t,
+			})
+	}
+	return nil
+}
+
+// Truncate changes the size of the file.
+//
+// If the file is larger than the given size, the extra data is discarded. If it is smaller, it is extended with zero
+// bytes, which may result in a sparse file.
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: there was a general error while working with the path
+//   - [PathTruncateError]: there was an error while changing the size of the file
+func (p Path) Truncate(size Size) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	err := fmt.Errorf("invalid size %s", size)
+	if size >= 0 {
+		err = p.fs().Truncate(p.FSPath, int64(size))
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathTruncateError, err, "failed to truncate '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file": p.FSPath,
+				"size": int64(size),
+			})
+	}
+	return nil
+}
+
+// Usage returns the combined size of the file or all of the files within the directory tree, similar to "du".
+//
+// Symbolic links are not followed.
+//
+// This function may return an error with any of the following codes:
+//   - [PathDiskUsageError]: there was an error while walking the directory tree
+func (p Path) Usage() (Size, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return 0, xerr
+	}
+	var total int64
+	err := walkFS(p.fs(), p.FSPath, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			total += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, xerrors.Wrapf(PathDiskUsageError, err, "failed to get disk usage for '%s': %s", p.FSPath,
+			err.Error()).WithAttr("path", p.FSPath)
+	}
+	return Size(total), nil
+}
+
+// VerifyChecksum computes the checksum of the file and compares it against the expected value.
+//
+// The expected value should be a hex-encoded digest prefixed by the algorithm, such as "sha256:9f86d0...". If the
+// prefix is omitted, SHA-256 or SHA-512 is assumed based on the length of the digest.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChecksumError]: the expected value is malformed, the algorithm is not supported or there was an error
+//     while reading the file
+//   - [PathChecksumMismatchError]: the checksum of the file does not match the expected value
+//   - [PathOpenFileError]: there was an error while opening the file
+func (p Path) VerifyChecksum(expected string) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	algo, digest, found := strings.Cut(expected, ":")
+	if !found {
+		digest = algo
+		switch len(digest) {
+		case sha256.Size * 2:
+			algo = string(ChecksumSHA256)
+		case sha512.Size * 2:
+			algo = string(ChecksumSHA512)
+		}
+	}
+	want, err := hex.DecodeString(strings.TrimSpace(digest))
+	if err != nil {
+		return xerrors.Wrapf(PathChecksumError, err, "failed to verify checksum of '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path":     p.FSPath,
+			"expected": expected,
+		})
+	}
+	got, xerr := p.Checksum(ChecksumAlgorithm(algo))
+	if xerr != nil {
+		return xerr
+	}
+	if subtle.ConstantTimeCompare(got, want) != 1 {
+		err := fmt.Errorf("expected %s checksum '%x' but got '%x'", algo, want, got)
+		return xerrors.Wrapf(PathChecksumMismatchError, err, "failed to verify checksum of '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path":     p.FSPath,
+			"expected": hex.EncodeToString(want),
+			"actual":   hex.EncodeToString(got),
+		})
+	}
+	return nil
+}
+
+// UnmarshalJSON parses the JSON data into a [Path] object.
+//
+// The data may either be an object containing the individual settings or a plain string. When a string is supplied,
+// it is used as the filesystem path and all other settings are set to the defaults used by [NewPath]. A JSON null
+// leaves the path unchanged.
+func (p *Path) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	var fsPath string
+	if err := json.Unmarshal(data, &fsPath); err == nil {
+		*p = NewPath(fsPath)
+		return nil
+	}
+
+	// pathObject prevents infinite recursion when unmarshaling the object form
+	type pathObject Path
+	var obj pathObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*p = Path(obj)
+	return nil
+}
+
+// UnmarshalText parses the text into a [Path] object.
+//
+// The text is used as the filesystem path and all other settings are set to the defaults used by [NewPath].
+func (p *Path) UnmarshalText(data []byte) error {
+	*p = NewPath(string(data))
+	return nil
+}
+
+// Walk walks the file tree rooted at the path, calling fn for each file or directory in the tree, including the
+// path itself.
+//
+// The files are walked in lexical order as described by [filepath.WalkDir]. Each [Path] passed to fn inherits all
+// other settings from this path. If fn returns [fs.SkipDir] or [fs.SkipAll], the walk behaves as described by
+// [filepath.WalkDir]. If fn returns an [xerrors.Error], the walk is stopped and that error is returned as-is.
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: there was an error while walking the tree or fn returned an error
+func (p Path) Walk(fn func(Path, fs.DirEntry) error) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	err := walkFS(p.fs(), p.FSPath, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return fn(p.withFSPath(name), d)
+	})
+	if err != nil {
+		if xerr, ok := err.(xerrors.Error); ok {
+			return xerr
+		}
+		return xerrors.Wrapf(PathError, err, "failed to walk '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+	return nil
+}
+
+// WithinRoot verifies that the path is the given root folder or is located within it.
+//
+// Both paths are cleaned and made absolute before being compared. Symbolic links are not resolved.
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: there was a general error while working with the path
+//   - [PathTraversalError]: the path is outside of the root folder
+func (p Path) WithinRoot(root string) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	target, err := filepath.Abs(p.FSPath)
+	if err == nil {
+		root, err = filepath.Abs(root)
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathError, err, "failed to convert '%s' to an absolute path: %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"root": root,
+		})
+	}
+	if rel, err := filepath.Rel(root, target); err != nil || !filepath.IsLocal(rel) {
+		err := fmt.Errorf("'%s' is not within '%s'", target, root)
+		return xerrors.Wrapf(PathTraversalError, err, "path traversal detected: %s", err.Error()).
+			WithAttrs(map[string]any{
+				"path": p.FSPath,
+				"root": root,
+			})
+	}
+	return nil
+}
+
+// WriteFile writes the given data the file.
+//
+// This function uses the [Path.OpenFS] function to create/open the file before writing to it. It automatically
+// closes the file after writing to it. If [Path.Compression] is set, the data is compressed.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathWriteError]: there was an error while writing to the file
+func (p Path) WriteFile(data []byte, overwrite bool) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	flags := os.O_CREATE | os.O_WRONLY
+	if overwrite {
+		flags |= os.O_TRUNC
+	} else {
+		flags |= os.O_APPEND
+	}
+	handle, xerr := p.OpenFS(flags)
+	if xerr != nil {
+		return xerr
+	}
+	defer handle.Close()
+
+	_, err := handle.Write(data)
+	if err == nil {
+		err = handle.Close()
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathWriteError, err, "failed to write to file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	return nil
+}
+
+// WriteFileAtomic replaces the contents of the file with the given data atomically.
+//
+// The data is written to a temporary file in the same folder, synced to disk and then renamed over the existing file
+// so that readers never see a partially written file, even after a crash. The temporary file is created with the
+// [Path.FileMode] permissions (subject to the umask) in the same way as [Path.OpenFS]. If [Path.Compression] is
+// set, the data is compressed.
+//
+// If [Path.AutoCreateParent] is true, [Path.MkdirAll] will be called on the file's parent folder first.
+// If [Path.AutoChmod] is true, the permissions will be set to the [Path.FileMode] value.
+// If [Path.AutoChown] is true, the ownership will be set to the [Path.Owner] and [Path.Group] values.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathWriteError]: there was an error while writing the file
+func (p Path) WriteFileAtomic(data []byte) xerrors.Error {
+	if xerr := p.autoExpand(); xerr != nil {
+		return xerr
+	}
+	if xerr := p.createParent(); xerr != nil {
+		return xerr
+	}
+
+	// write and sync the temporary file
+	dir := filepath.Dir(p.FSPath)
+	fsys := p.fs()
+	tmp, err := createTemp(fsys, dir, "."+filepath.Base(p.FSPath)+".tmp*", p.FileMode.OSFileMode())
+	if err != nil {
+		return xerrors.Wrapf(PathWriteError, err, "failed to write to file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	tmpPath := p.withFSPath(tmp.Name())
+	defer fsys.Remove(tmpPath.FSPath)
+
+	var w io.Writer = tmp
+	var cw io.WriteCloser
+	if p.Compression != CompressionNone {
+		var codec compressionCodec
+		if codec, err = p.Compression.codec(); err == nil {
+			cw, err = codec.newWriter(tmp)
+			w = cw
+		}
+	}
+	if err == nil {
+		_, err = w.Write(data)
+	}
+	if err == nil && cw != nil {
+		err = cw.Close()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathWriteError, err, "failed to write to file '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":     p.FSPath,
+				"tmp_file": tmpPath.FSPath,
+			})
+	}
+	if xerr := tmpPath.applyOwnership(); xerr != nil {
+		return xerr
+	}
+
+	// move the temporary file into place and make sure the rename itself is persisted
+	if err := fsys.Rename(tmpPath.FSPath, p.FSPath); err != nil {
+		return xerrors.Wrapf(PathWriteError, err, "failed to write to file '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":     p.FSPath,
+				"tmp_file": tmpPath.FSPath,
+			})
+	}
+	syncDir(fsys, dir)
+	return nil
+}
+
+// WriteJSON encodes v as indented JSON and atomically replaces the contents of the file with it.
+//
+// The file is written using [Path.WriteFileAtomic], so readers never see a partially written file.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathWriteError]: there was an error while encoding the value or writing the file
+func (p Path) WriteJSON(v any) xerrors.Error {
+	return p.encodeAndWrite(v, "JSON", func(v any) ([]byte, error) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	})
+}
+
+// WriteLines writes the given lines to the file, terminating each one with a newline.
+//
+// If overwrite is true, the existing contents of the file are replaced. Otherwise the lines are appended to the file.
+// See [Path.WriteFile] for details.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathWriteError]: there was an error while writing to the file
+func (p Path) WriteLines(lines []string, overwrite bool) xerrors.Error {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return p.WriteFile(buf.Bytes(), overwrite)
+}
+
+// WriteString writes the given string to the file.
+//
+// If overwrite is true, the existing contents of the file are replaced. Otherwise the string is appended to the file.
+// See [Path.WriteFile] for details.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathWriteError]: there was an error while writing to the file
+func (p Path) WriteString(s string, overwrite bool) xerrors.Error {
+	return p.WriteFile([]byte(s), overwrite)
+}
+
+// WriteYAML encodes v as YAML and atomically replaces the contents of the file with it.
+//
+// YAML is not supported by the standard library, so this function always fails unless a YAML library has been
+// registered with [RegisterYAML]. The file is written using [Path.WriteFileAtomic], so readers never see a partially
+// written file.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathWriteError]: there was an error while encoding the value or writing the file
+//   - [PathYAMLNotRegisteredError]: no YAML library has been registered
+func (p Path) WriteYAML(v any) xerrors.Error {
+	if !yamlRegistered() {
+		return p.yamlNotRegistered()
+	}
+	return p.encodeAndWrite(v, "YAML", marshalYAML)
+}
+
+// accessError converts the error returned while checking access to the path into an [xerrors.Error].
+func (p Path) accessError(err error, mode Access, who string) xerrors.Error {
+	if err == nil {
+		return nil
+	}
+	code := PathAccessDeniedError
+	msg := "'%s' does not have '%s' access to '%s': %s"
+	if errors.Is(err, fs.ErrNotExist) {
+		code = PathError
+		msg = "failed to check whether '%s' has '%s' access to '%s': %s"
+	}
+	return xerrors.Wrapf(code, err, msg, who, mode.String(), p.FSPath, err.Error()).WithAttrs(map[string]any{
+		"path":   p.FSPath,
+		"access": mode.String(),
+	})
+}
+
+// applyAll calls fn for the path and every file and folder within it, according to the given options.
+func (p Path) applyAll(opts RecursiveOptions, code int, action string, fn func(Path) xerrors.Error) xerrors.Error {
+	// collect everything in the tree up front
+	var paths []Path
+	err := walkFS(p.fs(), p.FSPath, func(name string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// never follow symbolic links found within the tree since they may point outside of it
+		child := p.withFSPath(name)
+		if name != p.FSPath {
+			child.FollowSymlinks = false
+		}
+		paths = append(paths, child)
+		return nil
+	})
+	if err != nil {
+		return xerrors.Wrapf(PathError, err, "failed to %s '%s': %s", action, p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+
+	// process the paths using the requested number of workers
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = 1
+	}
+	var (
+		errs   []error
+		failed atomic.Bool
+		mu     sync.Mutex
+		next   atomic.Int64
+		wg     sync.WaitGroup
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(paths) || (failed.Load() && !opts.ContinueOnError) {
+					return
+				}
+				if xerr := fn(paths[i]); xerr != nil {
+					failed.Store(true)
+					mu.Lock()
+					errs = append(errs, xerr)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	if len(errs) == 1 {
+		return errs[0].(xerrors.Error)
+	}
+	err = errors.Join(errs...)
+	return xerrors.Wrapf(code, err, "failed to %s %d paths in '%s': %s", action, len(errs), p.FSPath, err.Error()).
+		WithAttrs(map[string]any{
+			"path":   p.FSPath,
+			"errors": len(errs),
+		})
+}
+
+// applyOwnership changes the permissions and ownership of the path according to the [Path.AutoChmod] and
+// [Path.AutoChown] settings.
+func (p Path) applyOwnership() xerrors.Error {
+	if p.AutoChmod {
+		if xerr := p.Chmod(); xerr != nil {
+			return xerr
+		}
+	}
+	if p.AutoChown {
+		if xerr := p.Chown(); xerr != nil {
+			return xerr
+		}
+	}
+	return nil
+}
+
+// autoExpand expands the path if [Path.AutoExpand] is true.
+//
+// Once expanded, [Path.AutoExpand] is cleared so that the path is not expanded a second time.
+func (p *Path) autoExpand() xerrors.Error {
+	if !p.AutoExpand {
+		return nil
+	}
+	if xerr := p.Expand(); xerr != nil {
+		return xerr
+	}
+	p.AutoExpand = false
+	return nil
+}
+
+// copyContents copies the contents of the open source file to the destination, optionally syncing the destination
+// to disk before closing it.
+func (p Path) copyContents(src File, dest Path, sync bool) xerrors.Error {
+	out, xerr := dest.OpenFS(os.O_CREATE | os.O_WRONLY | os.O_TRUNC)
+	if xerr != nil {
+		return xerr
+	}
+	defer out.Close()
+
+	_, err := io.CopyBuffer(out, src, make([]byte, copyBufferSize))
+	if err == nil && sync {
+		err = out.Sync()
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		return xerrors.Wrapf(PathCopyError, err, "failed to copy '%s' to '%s': %s", p.FSPath, dest.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"dest": dest.FSPath,
+		})
+	}
+	return nil
+}
+
+// createParent creates the parent folder of the path if [Path.AutoCreateParent] is true.
+func (p Path) createParent() xerrors.Error {
+	if !p.AutoCreateParent {
+		return nil
+	}
+	parent := Path{
+		DirMode:        p.DirMode,
+		FollowSymlinks: p.FollowSymlinks,
+		FS:             p.FS,
+		Group:          p.Group,
+		Owner:          p.Owner,
+		FSPath:         filepath.Dir(p.FSPath),
+	}
+
+	// paths on other filesystems are always slash-separated
+	if !isOSFS(p.fs()) {
+		parent.FSPath = path.Dir(p.FSPath)
+	}
+	return parent.MkdirAll()
+}
+
+// encodeAndWrite encodes v using the given marshal function and atomically replaces the contents of the file with it.
+func (p Path) encodeAndWrite(v any, format string, marshal func(any) ([]byte, error)) xerrors.Error {
+	data, err := marshal(v)
+	if err != nil {
+		return xerrors.Wrapf(PathWriteError, err, "failed to encode %s for file '%s': %s", format, p.FSPath,
+			err.Error()).WithAttr("file", p.FSPath)
+	}
+	return p.WriteFileAtomic(data)
+}
+
+// finalizeTemp returns a copy of the path pointing to the newly created temporary file or directory after applying
+// the configured permissions and ownership to it.
+//
+// If the permissions or ownership cannot be applied, the temporary file or directory is removed.
+func (p Path) finalizeTemp(name string) (Path, xerrors.Error) {
+	tmp := p.withFSPath(name)
+	tmp.AutoChmod = true
+	xerr := tmp.applyOwnership()
+	tmp.AutoChmod = p.AutoChmod
+	if xerr != nil {
+		p.fs().RemoveAll(name)
+		return Path{}, xerr
+	}
+	return tmp, nil
+}
+
+// fs returns the filesystem on which operations should be performed.
+func (p Path) fs() FS {
+	if p.FS == nil {
+		return OSFS{}
+	}
+	return p.FS
+}
+
+// moveDir moves the directory to dest by copying everything within it and then removing it, which is used when the
+// directory cannot be renamed because dest is on a different filesystem.
+//
+// Copied files are synced to disk before the source is removed. If anything cannot be copied, dest is removed again
+// and the source is left untouched.
+func (p Path) moveDir(dest Path, info fs.FileInfo) xerrors.Error {
+	destFS := dest.fs()
+	if _, err := destFS.Lstat(dest.FSPath); !errors.Is(err, fs.ErrNotExist) {
+		if err == nil {
+			err = fmt.Errorf("'%s' %w", dest.FSPath, fs.ErrExist)
+		}
+		return xerrors.Wrapf(PathMoveError, err, "failed to move '%s' to '%s': %s", p.FSPath, dest.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"dest": dest.FSPath,
+		})
+	}
+	if dest.DirMode == 0 {
+		dest.DirMode = FileModeOf(info.Mode())
+	}
+
+	// recreate the tree at the destination
+	var dirs []string
+	xerr := p.Walk(func(child Path, d fs.DirEntry) error {
+		rel, err := filepath.Rel(p.FSPath, child.FSPath)
+		var childInfo fs.FileInfo
+		if err == nil {
+			childInfo, err = d.Info()
+		}
+		if err != nil {
+			return xerrors.Wrapf(PathCopyError, err, "failed to copy '%s': %s", child.FSPath, err.Error()).
+				WithAttr("path", child.FSPath)
+		}
+		target := dest.withFSPath(filepath.Join(dest.FSPath, rel))
+		target.AutoCreateParent = false
+		target.Compression = CompressionNone
+
+		switch {
+		case childInfo.IsDir():
+			if rel != "." {
+				target.DirMode = FileModeOf(childInfo.Mode())
+			}
+			if err := destFS.Mkdir(target.FSPath, target.DirMode.OSFileMode()); err != nil {
+				return xerrors.Wrapf(PathCreateError, err, "failed to create path '%s': %s", target.FSPath,
+					err.Error()).WithAttrs(map[string]any{
+					"path":     target.FSPath,
+					"dir_mode": fmt.Sprintf("%o", target.DirMode),
+				})
+			}
+			dirs = append(dirs, target.FSPath)
+			return target.applyOwnership()
+		case childInfo.Mode()&fs.ModeSymlink != 0:
+			link, err := p.fs().Readlink(child.FSPath)
+			if err != nil {
+				return xerrors.Wrapf(PathSymlinkError, err, "failed to read link '%s': %s", child.FSPath,
+					err.Error()).WithAttr("path", child.FSPath)
+			}
+			return target.Symlink(link)
+		case childInfo.Mode().IsRegular():
+			src, err := p.fs().Open(child.FSPath)
+			if err != nil {
+				return xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", child.FSPath,
+					err.Error()).WithAttr("file", child.FSPath)
+			}
+			defer src.Close()
+			target.FileMode = FileModeOf(childInfo.Mode())
+			return child.copyContents(src, target, true)
+		}
+		err = fmt.Errorf("'%s' is not a regular file, folder or symbolic link", child.FSPath)
+		return xerrors.Wrapf(PathCopyError, err, "failed to copy '%s' to '%s': %s", child.FSPath, target.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path": child.FSPath,
+			"dest": target.FSPath,
+		})
+	})
+	if xerr != nil {
+		destFS.RemoveAll(dest.FSPath)
+		return xerr
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		syncDir(destFS, dirs[i])
+	}
+	syncDir(destFS, filepath.Dir(dest.FSPath))
+
+	// the copy is complete so the source can be removed
+	if err := p.fs().RemoveAll(p.FSPath); err != nil {
+		return xerrors.Wrapf(PathMovePartialError, err, "copied '%s' to '%s' but failed to remove the source: %s",
+			p.FSPath, dest.FSPath, err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"dest": dest.FSPath,
+		})
+	}
+	return nil
+}
+
+// openSource opens the file so that it can be copied to dest.
+//
+// If dest has no [Path.FileMode] set, it is updated to use the permissions of the source file, including the setuid,
+// setgid and sticky bits. An error is returned if dest refers to the same file as the path.
+//
+// If both paths use the same [Path.Compression] format, dest.Compression is cleared so that the raw bytes are copied
+// unchanged. Otherwise the returned file decompresses the source as needed and dest compresses what is written to it.
+func (p Path) openSource(dest *Path) (File, xerrors.Error) {
+	src, err := p.fs().Open(p.FSPath)
+	if err != nil {
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	info, err := src.Stat()
+	if err != nil {
+		src.Close()
+		return nil, xerrors.Wrapf(PathError, err, "failed to copy '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+	if info.IsDir() {
+		src.Close()
+		err := fmt.Errorf("'%s' is a directory", p.FSPath)
+		return nil, xerrors.Wrapf(PathCopyError, err, "failed to copy '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+
+	// opening the destination truncates it, so copying a file onto itself would destroy the source
+	if same, _ := p.Same(*dest); same {
+		src.Close()
+		err := errors.New("source and destination are the same file")
+		return nil, xerrors.Wrapf(PathCopyError, err, "failed to copy '%s' to '%s': %s", p.FSPath, dest.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"path": p.FSPath,
+			"dest": dest.FSPath,
+		})
+	}
+
+	// preserve the source permissions unless the destination overrides them
+	if dest.FileMode == 0 {
+		dest.FileMode = FileModeOf(info.Mode())
+	}
+
+	// copy the raw bytes if both files use the same format, otherwise convert from one format to the other
+	if p.Compression.normalize() == dest.Compression.normalize() {
+		dest.Compression = CompressionNone
+		return src, nil
+	}
+	if p.Compression == CompressionNone {
+		return src, nil
+	}
+	csrc, err := p.Compression.wrap(src, os.O_RDONLY)
+	if err != nil {
+		src.Close()
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":        p.FSPath,
+				"compression": p.Compression.String(),
+			})
+	}
+	return csrc, nil
+}
+
+// readAndDecode reads the file and decodes its contents into v using the given unmarshal function.
+func (p Path) readAndDecode(v any, format string, unmarshal func([]byte, any) error) xerrors.Error {
+	data, xerr := p.ReadFile()
+	if xerr != nil {
+		return xerr
+	}
+	if err := unmarshal(data, v); err != nil {
+		return xerrors.Wrapf(PathReadError, err, "failed to decode %s file '%s': %s", format, p.FSPath,
+			err.Error()).WithAttr("file", p.FSPath)
+	}
+	return nil
+}
+
+// withFSPath returns a copy of the path which points to the given filesystem path instead.
+func (p Path) withFSPath(fsPath string) Path {
+	child := p
+	child.FSPath = fsPath
+	return child
+}
+
+// yamlNotRegistered returns the error used when a YAML file is read or written before a YAML library has been
+// registered with [RegisterYAML].
+func (p Path) yamlNotRegistered() xerrors.Error {
+	return xerrors.Wrapf(PathYAMLNotRegisteredError, errYAMLNotRegistered, "failed to use YAML file '%s': %s",
+		p.FSPath, errYAMLNotRegistered.Error()).WithAttr("file", p.FSPath)
+}
+
+// expandPath expands a leading "~" or "~user" in the path to the corresponding user's home directory and replaces
+// any environment variable references with their values.
+func expandPath(p string) (string, error) {
+	if !strings.HasPrefix(p, "~") {
+		return os.ExpandEnv(p), nil
+	}
+
+	// split the user name from the remainder of the path
+	name, rest := p[1:], ""
+	isSeparator := func(r rune) bool {
+		return r < 128 && os.IsPathSeparator(uint8(r))
+	}
+	if i := strings.IndexFunc(name, isSeparator); i >= 0 {
+		name, rest = name[:i], name[i:]
+	}
+
+	// lookup the home directory
+	var home string
+	if name == "" {
+		dir, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		home = dir
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to lookup user named '%s': %w", name, err)
+		}
+		home = u.HomeDir
+	}
+	return home + os.ExpandEnv(rest), nil
+}
+
+// isProtectedPath returns whether or not the given absolute path is one which should never be removed.
+func isProtectedPath(p string) bool {
+	if p == filepath.VolumeName(p)+string(filepath.Separator) {
+		return true
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if home, err = resolvePath(home); err == nil && p == home {
+			return true
+		}
+	}
+	return false
+}
+
+// resolvePath converts the given path to a clean, absolute path with any symbolic links in its parent folders
+// resolved.
+func resolvePath(p string) (string, error) {
+	p, err := filepath.Abs(p)
+	if err != nil {
+		return "", err
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(p))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return p, nil
+		}
+		return "", err
+	}
+	return filepath.Join(dir, filepath.Base(p)), nil
+}
+
+// syncDir attempts to flush the directory entry changes for the given folder to disk.
+//
+// Not all platforms support syncing directories so any errors are ignored.
+func syncDir(fsys FS, dir string) {
+	d, err := fsys.Open(dir)
+	if err != nil {
+		return
+	}
+	d.Sync()
+	d.Close()
+}
@@ -0,0 +1,163 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"go.innotegrity.dev/xerrors"
+)
+
+// EnsureActions describes the actions taken by [Path.EnsureDir] and [Path.EnsureFile].
+//
+// Values may be combined, e.g. EnsureCreated | EnsureChowned.
+type EnsureActions int
+
+const (
+	// EnsureCreated indicates the file or folder was created.
+	EnsureCreated EnsureActions = 1 << iota
+
+	// EnsureChmodded indicates the permissions of the existing file or folder were changed.
+	EnsureChmodded
+
+	// EnsureChowned indicates the ownership of the existing file or folder was changed.
+	EnsureChowned
+)
+
+// Has returns whether or not all of the given actions were taken.
+func (a EnsureActions) Has(actions EnsureActions) bool {
+	return a&actions == actions
+}
+
+// String returns the [EnsureActions] object as a string, such as "created" or "chmodded,chowned".
+func (a EnsureActions) String() string {
+	if a == 0 {
+		return "none"
+	}
+	var names []string
+	if a.Has(EnsureCreated) {
+		names = append(names, "created")
+	}
+	if a.Has(EnsureChmodded) {
+		names = append(names, "chmodded")
+	}
+	if a.Has(EnsureChowned) {
+		names = append(names, "chowned")
+	}
+	return strings.Join(names, ",")
+}
+
+// EnsureDir makes sure the path exists as a folder, creating it and any parent folders if necessary.
+//
+// If the folder already exists, its permissions are changed to [Path.DirMode] if [Path.AutoChmod] is true and they
+// differ, and its ownership is changed to [Path.Owner] and [Path.Group] if [Path.AutoChown] is true and it differs.
+// The actions which were taken are returned so that they can be logged. Calling this function again once the folder
+// is in the desired state does nothing.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the folder
+//   - [PathChownError]: there was an error while changing ownership of the folder
+//   - [PathCreateError]: there was an error while creating the folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathTypeError]: the path exists but is not a folder
+func (p Path) EnsureDir() (EnsureActions, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return 0, xerr
+	}
+	info, err := p.fs().Stat(p.FSPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if xerr := p.MkdirAll(); xerr != nil {
+			return 0, xerr
+		}
+		return EnsureCreated, nil
+	}
+	return p.reconcile(info, err, true)
+}
+
+// EnsureFile makes sure the path exists as a file, creating an empty file if necessary.
+//
+// The file is created using [Path.OpenFS], so the [Path.AutoCreateParent], [Path.AutoChmod] and [Path.AutoChown]
+// settings are honored. If the file already exists, its contents are left untouched but its permissions and ownership
+// are reconciled as described by [Path.EnsureDir], using [Path.FileMode] rather than [Path.DirMode].
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathOpenFileError]: there was an error while creating the file
+//   - [PathTypeError]: the path exists but is not a regular file
+func (p Path) EnsureFile() (EnsureActions, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return 0, xerr
+	}
+	info, err := p.fs().Stat(p.FSPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		p.Compression = CompressionNone
+		file, xerr := p.OpenFS(os.O_CREATE | os.O_EXCL | os.O_WRONLY)
+		if xerr == nil {
+			file.Close()
+			return EnsureCreated, nil
+		}
+		if xerr.Code() != PathExistsError {
+			return 0, xerr
+		}
+
+		// the file was created by someone else in the meantime
+		info, err = p.fs().Stat(p.FSPath)
+	}
+	return p.reconcile(info, err, false)
+}
+
+// reconcile checks the type of the existing path and updates its permissions and ownership as necessary.
+func (p Path) reconcile(info fs.FileInfo, err error, dir bool) (EnsureActions, xerrors.Error) {
+	if err != nil {
+		return 0, xerrors.Wrapf(PathError, err, "failed to check path '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+	mode := p.FileMode
+	if dir {
+		mode = p.DirMode
+	}
+	if info.IsDir() != dir || (!dir && !info.Mode().IsRegular()) {
+		kind := "a regular file"
+		if dir {
+			kind = "a folder"
+		}
+		err := fmt.Errorf("'%s' exists but is not %s", p.FSPath, kind)
+		return 0, xerrors.Wrapf(PathTypeError, err, "failed to ensure path '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"path": p.FSPath,
+				"mode": info.Mode().String(),
+			})
+	}
+
+	// the path is followed when checking it, so ownership and permissions must also be applied to the target
+	p.FollowSymlinks = true
+	var actions EnsureActions
+	const modeMask = fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+	if p.AutoChmod && info.Mode()&modeMask != mode.OSFileMode()&modeMask {
+		if xerr := p.Chmod(); xerr != nil {
+			return actions, xerr
+		}
+		actions |= EnsureChmodded
+	}
+	if p.AutoChown {
+		uid, gid, ok := fileOwner(info)
+		if !ok || uid != int(p.Owner) || gid != int(p.Group) {
+			if xerr := p.Chown(); xerr != nil {
+				return actions, xerr
+			}
+
+			// ownership is silently left alone when not running as root, so check it was actually changed
+			if info, err := p.fs().Stat(p.FSPath); ok && err == nil {
+				if uid, gid, _ := fileOwner(info); uid == int(p.Owner) && gid == int(p.Group) {
+					actions |= EnsureChowned
+				}
+			}
+		}
+	}
+	return actions, nil
+}
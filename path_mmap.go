@@ -0,0 +1,105 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sync"
+
+	"go.innotegrity.dev/xerrors"
+)
+
+// MappedFile holds the read-only contents of a file which has been mapped into memory by [Path.Mmap].
+//
+// The data returned by [MappedFile.Bytes] must not be modified and must not be used after [MappedFile.Close] is
+// called.
+type MappedFile struct {
+	data      []byte
+	closeOnce sync.Once
+	unmap     func() error
+}
+
+// Bytes returns the contents of the file.
+func (m *MappedFile) Bytes() []byte {
+	return m.data
+}
+
+// Close unmaps the file from memory.
+//
+// It is safe to call Close more than once.
+func (m *MappedFile) Close() error {
+	var err error
+	m.closeOnce.Do(func() {
+		if m.unmap != nil {
+			err = m.unmap()
+		}
+		m.data = nil
+	})
+	return err
+}
+
+// Len returns the size of the file in bytes.
+func (m *MappedFile) Len() int {
+	return len(m.data)
+}
+
+// Mmap maps the contents of the file into memory for read-only access without copying it.
+//
+// Memory mapping is only used on Linux, MacOS, FreeBSD and Windows when the path is on the operating system's
+// filesystem and [Path.Compression] is not set. Otherwise the contents of the file are read into memory instead so
+// that callers do not need to handle those cases separately.
+//
+// The returned [MappedFile] must be closed when it is no longer needed.
+//
+// This function may return an error with any of the following codes:
+//   - [PathFileTooLargeError]: the file is too large to be mapped into memory
+//   - [PathOpenFileError]: there was an error while opening the file
+//   - [PathReadError]: there was an error while mapping or reading the file
+func (p Path) Mmap() (*MappedFile, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+	if !isOSFS(p.fs()) || p.Compression != CompressionNone {
+		data, xerr := p.ReadFile()
+		if xerr != nil {
+			return nil, xerr
+		}
+		return &MappedFile{data: data}, nil
+	}
+
+	file, err := os.Open(p.FSPath)
+	if err != nil {
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, xerrors.Wrapf(PathReadError, err, "failed to map file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	if info.IsDir() {
+		err := fmt.Errorf("'%s' is a directory", p.FSPath)
+		return nil, xerrors.Wrapf(PathReadError, err, "failed to map file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	size := info.Size()
+	if size > math.MaxInt {
+		err := fmt.Errorf("file size %s exceeds the maximum size which can be mapped", Size(size))
+		return nil, xerrors.Wrapf(PathFileTooLargeError, err, "failed to map file '%s': %s", p.FSPath,
+			err.Error()).WithAttrs(map[string]any{
+			"file": p.FSPath,
+			"size": size,
+		})
+	}
+	if size == 0 {
+		return &MappedFile{data: []byte{}}, nil
+	}
+
+	data, unmap, err := mmapFile(file, int(size))
+	if err != nil {
+		return nil, xerrors.Wrapf(PathReadError, err, "failed to map file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	return &MappedFile{data: data, unmap: unmap}, nil
+}
@@ -0,0 +1,44 @@
+//go:build !darwin && !freebsd && !linux && !windows
+
+package types
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// errUnsupportedPlatform is returned by functions which are not implemented on the current platform.
+var errUnsupportedPlatform = errors.New("operation is not supported on this platform")
+
+// access checks whether the given path may be accessed by evaluating the owner permission bits of the file.
+func access(path string, mode Access) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !mode.permits(info.Mode().Perm() >> 6) {
+		return fs.ErrPermission
+	}
+	return nil
+}
+
+// diskSpace is not supported on this platform.
+func diskSpace(path string) (uint64, uint64, error) {
+	return 0, 0, errUnsupportedPlatform
+}
+
+// fileOwner is not supported on this platform.
+func fileOwner(info fs.FileInfo) (int, int, bool) {
+	return 0, 0, false
+}
+
+// mmapFile reads the contents of the file into memory since memory mapping is not supported on this platform.
+func mmapFile(file *os.File, size int) ([]byte, func() error, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(file, data); err != nil {
+		return nil, nil, err
+	}
+	return data, nil, nil
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+
+package types
+
+import "os"
+
+// chown changes the ownership of the path to the configured owner and group.
+//
+// Only the root user may change ownership on the operating system's filesystem, so nothing is changed when running as
+// any other user. If [Path.StrictOwnership] is true, errChownSkipped is returned in that case.
+func (p Path) chown() error {
+	fsys := p.fs()
+	if isOSFS(fsys) && os.Geteuid() != 0 {
+		if p.StrictOwnership {
+			return errChownSkipped
+		}
+		return nil
+	}
+	if p.FollowSymlinks {
+		return fsys.Chown(p.FSPath, int(p.Owner), int(p.Group))
+	}
+	return fsys.Lchown(p.FSPath, int(p.Owner), int(p.Group))
+}
+
+// setACL does nothing since access control lists are only applied on Windows.
+func setACL(name string, mode os.FileMode) error {
+	return nil
+}
@@ -0,0 +1,167 @@
+package types
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+
+	"go.innotegrity.dev/xerrors"
+)
+
+// defaultTailInterval is the default interval at which a tailed file is checked for new data.
+const defaultTailInterval = Duration(250 * time.Millisecond)
+
+// TailLine holds a single line read from a file being tailed.
+type TailLine struct {
+	// Text is the contents of the line without the trailing line ending.
+	Text string
+
+	// Time is the time at which the line was read.
+	Time time.Time
+}
+
+// TailOptions holds the settings used when tailing a file.
+type TailOptions struct {
+	// FromStart indicates if the existing contents of the file should be read rather than only lines written after
+	// tailing starts.
+	FromStart bool `json:"from_start" yaml:"from_start" mapstructure:"from_start"`
+
+	// Interval is how often the file is checked for new data once the end has been reached. If 0, the file is
+	// checked every 250 milliseconds.
+	Interval Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+}
+
+// Tail follows the file as it grows and sends each new line on the returned channel, similar to "tail -F".
+//
+// If the file is rotated (replaced by a new file) or truncated, reading continues from the start of the new
+// contents. If the file does not exist yet or is temporarily removed, it is opened once it appears. Tailing stops and
+// the channel is closed when ctx is cancelled.
+//
+// The file is read using [Path.FS] if it is set. Since only the operating system's filesystem exposes the identity of
+// a file, rotation is not detected on any other filesystem, although truncation is.
+//
+// This function may return an error with any of the following codes:
+//   - [PathOpenFileError]: there was an error while opening the file
+func (p Path) Tail(ctx context.Context, opts TailOptions) (<-chan TailLine, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = defaultTailInterval
+	}
+	file, err := p.fs().Open(p.FSPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttr("file", p.FSPath)
+	}
+	if file != nil && !opts.FromStart {
+		if _, err := file.Seek(0, io.SeekEnd); err != nil {
+			file.Close()
+			return nil, xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", p.FSPath,
+				err.Error()).WithAttr("file", p.FSPath)
+		}
+	}
+
+	lines := make(chan TailLine)
+	go p.tail(ctx, file, time.Duration(opts.Interval), lines)
+	return lines, nil
+}
+
+// tail reads lines from the file and sends them on the given channel until ctx is cancelled.
+func (p Path) tail(ctx context.Context, file File, interval time.Duration, lines chan<- TailLine) {
+	defer close(lines)
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	var reader *bufio.Reader
+	if file != nil {
+		reader = bufio.NewReader(file)
+	}
+	var partial strings.Builder
+	var draining bool
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	// wait pauses before checking the file again, returning false if tailing should stop
+	wait := func() bool {
+		timer.Reset(interval)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+			return true
+		}
+	}
+
+	for {
+		// (re)open the file if we do not currently have it open
+		if file == nil {
+			f, err := p.fs().Open(p.FSPath)
+			if err != nil {
+				if !wait() {
+					return
+				}
+				continue
+			}
+			file = f
+			reader = bufio.NewReader(file)
+			draining = false
+		}
+
+		// read a full line
+		data, err := reader.ReadString('\n')
+		partial.WriteString(data)
+		if err == nil {
+			line := TailLine{Text: strings.TrimRight(partial.String(), "\r\n"), Time: time.Now()}
+			partial.Reset()
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			file.Close()
+			file = nil
+			if !wait() {
+				return
+			}
+			continue
+		}
+
+		// we've reached the end of the file so check if it was rotated or truncated
+		current, statErr := file.Stat()
+		latest, err := p.fs().Stat(p.FSPath)
+		if statErr == nil && err == nil {
+			offset, _ := file.Seek(0, io.SeekCurrent)
+			if !sameFile(p.fs(), current, latest) {
+				// read anything written to the old file before it was replaced
+				if !draining {
+					draining = true
+					continue
+				}
+				file.Close()
+				file = nil
+				partial.Reset()
+				continue
+			}
+			if latest.Size() < offset {
+				file.Seek(0, io.SeekStart)
+				reader.Reset(file)
+				partial.Reset()
+			}
+		}
+		if !wait() {
+			return
+		}
+	}
+}
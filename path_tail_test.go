@@ -0,0 +1,145 @@
+package types_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+// appendString appends the text to the file, creating it if needed.
+func appendString(t *testing.T, name, text string) {
+	t.Helper()
+	file, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("failed to open file: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(text); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+// expectTailLines fails the test unless the given lines are the next ones received from the channel.
+func expectTailLines(t *testing.T, lines <-chan types.TailLine, want ...string) {
+	t.Helper()
+	for _, text := range want {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatalf("expected line %q but the channel was closed", text)
+			}
+			if line.Text != text {
+				t.Errorf("expected line %q but got %q", text, line.Text)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for line %q", text)
+		}
+	}
+}
+
+// expectTailClosed fails the test unless the channel is closed, discarding any lines still being sent.
+func expectTailClosed(t *testing.T, lines <-chan types.TailLine) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-lines:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for the channel to be closed")
+		}
+	}
+}
+
+func TestPathTail1(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "app.log"))
+	appendString(t, p.FSPath, "existing\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lines, xerr := p.Tail(ctx, types.TailOptions{Interval: types.Duration(10 * time.Millisecond)})
+	if xerr != nil {
+		t.Fatalf("failed to tail file: %v", xerr)
+	}
+
+	// only lines appended after tailing starts are sent, and partial lines are held until they are complete
+	appendString(t, p.FSPath, "first\nsec")
+	expectTailLines(t, lines, "first")
+	appendString(t, p.FSPath, "ond\r\nthird\n")
+	expectTailLines(t, lines, "second", "third")
+}
+
+func TestPathTail2(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "app.log"))
+	appendString(t, p.FSPath, "first\nsecond\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lines, xerr := p.Tail(ctx, types.TailOptions{FromStart: true, Interval: types.Duration(10 * time.Millisecond)})
+	if xerr != nil {
+		t.Fatalf("failed to tail file: %v", xerr)
+	}
+	expectTailLines(t, lines, "first", "second")
+
+	// reading starts over once the file is truncated
+	if err := os.Truncate(p.FSPath, 0); err != nil {
+		t.Fatalf("failed to truncate file: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	appendString(t, p.FSPath, "third\n")
+	expectTailLines(t, lines, "third")
+}
+
+func TestPathTail3(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "app.log"))
+	appendString(t, p.FSPath, "first\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lines, xerr := p.Tail(ctx, types.TailOptions{FromStart: true, Interval: types.Duration(10 * time.Millisecond)})
+	if xerr != nil {
+		t.Fatalf("failed to tail file: %v", xerr)
+	}
+	expectTailLines(t, lines, "first")
+
+	// lines written to the old file after it is renamed are read before the new file
+	backup := p.FSPath + ".1"
+	if err := os.Rename(p.FSPath, backup); err != nil {
+		t.Fatalf("failed to rename file: %v", err)
+	}
+	appendString(t, backup, "second\n")
+	appendString(t, p.FSPath, "third\n")
+	expectTailLines(t, lines, "second", "third")
+	appendString(t, p.FSPath, "fourth\n")
+	expectTailLines(t, lines, "fourth")
+}
+
+func TestPathTail4(t *testing.T) {
+	dir := t.TempDir()
+
+	// tailing stops when waiting for a file which does not exist
+	p := types.NewPath(filepath.Join(dir, "missing.log"))
+	ctx, cancel := context.WithCancel(context.Background())
+	lines, xerr := p.Tail(ctx, types.TailOptions{Interval: types.Duration(10 * time.Millisecond)})
+	if xerr != nil {
+		t.Fatalf("failed to tail file: %v", xerr)
+	}
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+	expectTailClosed(t, lines)
+
+	// tailing stops when no one is receiving the lines being sent
+	p = types.NewPath(filepath.Join(dir, "app.log"))
+	appendString(t, p.FSPath, "first\nsecond\n")
+	ctx, cancel = context.WithCancel(context.Background())
+	lines, xerr = p.Tail(ctx, types.TailOptions{FromStart: true, Interval: types.Duration(10 * time.Millisecond)})
+	if xerr != nil {
+		t.Fatalf("failed to tail file: %v", xerr)
+	}
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+	expectTailClosed(t, lines)
+}
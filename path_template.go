@@ -0,0 +1,81 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"go.innotegrity.dev/xerrors"
+)
+
+// pathTemplateToken matches a placeholder such as "{{date}}" within a path template.
+var pathTemplateToken = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// NewPathTemplate creates a new [Path] object with sensible defaults by rendering the given path template.
+//
+// See [Path.Render] for the placeholders which are supported.
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: the template contains an unknown placeholder or a placeholder could not be rendered
+func NewPathTemplate(tmpl string) (Path, xerrors.Error) {
+	return NewPath(tmpl).Render()
+}
+
+// Render returns a copy of the path with any placeholders in [Path.FSPath] replaced by their values.
+//
+// The following placeholders are supported:
+//
+//	{{date}} = the current date (e.g. 2006-01-02)
+//	{{time}} = the current time (e.g. 150405)
+//	{{datetime}} = the current date and time (e.g. 20060102T150405)
+//	{{unix}} = the current time in seconds since the Unix epoch
+//	{{uuid}} = a new UUID (see [NewUUID])
+//	{{hostname}} = the host name reported by the kernel
+//	{{pid}} = the ID of the current process
+//
+// All of the time-based placeholders use the same local time, so a single path never contains a mix of values
+// from different seconds. Each "{{uuid}}" placeholder is replaced by a different UUID.
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: the template contains an unknown placeholder or a placeholder could not be rendered
+func (p Path) Render() (Path, xerrors.Error) {
+	now := time.Now()
+	var renderErr error
+	fsPath := pathTemplateToken.ReplaceAllStringFunc(p.FSPath, func(token string) string {
+		if renderErr != nil {
+			return token
+		}
+		switch name := pathTemplateToken.FindStringSubmatch(token)[1]; name {
+		case "date":
+			return now.Format("2006-01-02")
+		case "time":
+			return now.Format("150405")
+		case "datetime":
+			return now.Format("20060102T150405")
+		case "unix":
+			return strconv.FormatInt(now.Unix(), 10)
+		case "uuid":
+			return NewUUID()
+		case "hostname":
+			hostname, err := os.Hostname()
+			if err != nil {
+				renderErr = fmt.Errorf("failed to get host name: %w", err)
+				return token
+			}
+			return hostname
+		case "pid":
+			return strconv.Itoa(os.Getpid())
+		default:
+			renderErr = errors.New("unknown placeholder '" + token + "'")
+			return token
+		}
+	})
+	if renderErr != nil {
+		return p, xerrors.Wrapf(PathError, renderErr, "failed to render path template '%s': %s", p.FSPath,
+			renderErr.Error()).WithAttr("path", p.FSPath)
+	}
+	return p.withFSPath(fsPath), nil
+}
@@ -0,0 +1,857 @@
+package types_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+// TODO: implement additional testing and benchmarks
+
+func TestPath1(t *testing.T) {
+	fsys := types.ReadOnlyFS(fstest.MapFS{
+		"etc/app/config.json": &fstest.MapFile{Data: []byte(`{"debug": true}`), Mode: 0644},
+		"etc/app/extra.json":  &fstest.MapFile{Data: []byte(`{}`), Mode: 0644},
+		"etc/app/README":      &fstest.MapFile{Data: []byte("docs"), Mode: 0644},
+	})
+	dir := types.Path{FS: fsys, FSPath: "/etc/app"}
+
+	matches, xerr := dir.Glob("*.json")
+	if xerr != nil {
+		t.Fatalf("failed to glob files: %v", xerr)
+	}
+	if len(matches) != 2 {
+		t.Errorf("expected 2 matches but got %d", len(matches))
+	}
+
+	usage, xerr := dir.Usage()
+	if xerr != nil {
+		t.Fatalf("failed to compute usage: %v", xerr)
+	}
+	t.Logf("usage: %s", usage)
+
+	checksum, xerr := matches[0].Checksum(types.ChecksumSHA256)
+	if xerr != nil {
+		t.Fatalf("failed to compute checksum: %v", xerr)
+	}
+	t.Logf("checksum of %s: %x", matches[0].FSPath, checksum)
+
+	if xerr := matches[0].WriteFile([]byte("{}"), true); xerr == nil {
+		t.Errorf("expected write to read-only filesystem to fail")
+	}
+}
+
+func TestPath2(t *testing.T) {
+	fsys := types.ReadOnlyFS(fstest.MapFS{
+		"config.json": &fstest.MapFile{Data: []byte(`{"debug": true}`), Mode: 0644},
+	})
+	p := types.Path{FS: fsys, FSPath: "config.json"}
+
+	if _, xerr := p.ReadFileMax(types.Size(10)); xerr == nil {
+		t.Errorf("expected file larger than limit to fail")
+	}
+	data, xerr := p.ReadFileMax(types.Size(1024))
+	if xerr != nil {
+		t.Fatalf("failed to read file: %v", xerr)
+	}
+	t.Logf("contents: %s", data)
+}
+
+func TestPath3(t *testing.T) {
+	root := types.Path{FSPath: "/var/lib/app"}
+	for _, elems := range [][]string{{"data", "file.txt"}, {"a/../b"}, {"..", "etc", "passwd"}, {"/etc/passwd"}} {
+		child, xerr := root.Join(elems...)
+		if xerr != nil {
+			t.Logf("rejected %v: %v", elems, xerr)
+			continue
+		}
+		if xerr := child.WithinRoot(root.FSPath); xerr != nil {
+			t.Errorf("joined path %s is not within root: %v", child.FSPath, xerr)
+		}
+		t.Logf("joined %v: %s", elems, child.FSPath)
+	}
+}
+
+func TestPath4(t *testing.T) {
+	var config struct {
+		Data types.Path `json:"data"`
+		Logs types.Path `json:"logs"`
+	}
+	data := []byte(`{"data": "/var/lib/app/data", "logs": {"path": "/var/log/app", "dir_mode": 448}}`)
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("failed to unmarshal paths: %v", err)
+	}
+	if config.Data.FSPath != "/var/lib/app/data" || config.Data.DirMode != 0755 {
+		t.Errorf("unexpected path from string: %+v", config.Data)
+	}
+	if config.Logs.FSPath != "/var/log/app" || config.Logs.DirMode != 0700 {
+		t.Errorf("unexpected path from object: %+v", config.Logs)
+	}
+
+	if err := json.Unmarshal([]byte(`{"logs": null}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal null path: %v", err)
+	}
+	if config.Logs.FSPath != "/var/log/app" || config.Logs.DirMode != 0700 {
+		t.Errorf("expected null to leave the path unchanged but got: %+v", config.Logs)
+	}
+}
+
+func TestPath5(t *testing.T) {
+	fsys := types.ReadOnlyFS(fstest.MapFS{
+		"etc/app/config.json": &fstest.MapFile{Data: []byte(`{"debug": true}`), Mode: 0644},
+		"etc/app/config.yaml": &fstest.MapFile{Data: []byte("debug: true"), Mode: 0644},
+	})
+	var config struct {
+		Debug bool `json:"debug"`
+	}
+	if xerr := (types.Path{FS: fsys, FSPath: "/etc/app/config.json"}).ReadJSON(&config); xerr != nil {
+		t.Fatalf("failed to read JSON file: %v", xerr)
+	}
+	if !config.Debug {
+		t.Errorf("expected debug to be true")
+	}
+	if xerr := (types.Path{FS: fsys, FSPath: "/etc/app/config.yaml"}).ReadYAML(&config); xerr == nil ||
+		xerr.Code() != types.PathYAMLNotRegisteredError {
+		t.Errorf("expected reading YAML without a registered library to fail but got: %v", xerr)
+	}
+}
+
+func TestPath6(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "lock"))
+	file, xerr := p.Open(types.OpenCreateExclusive)
+	if xerr != nil {
+		t.Fatalf("failed to create file: %v", xerr)
+	}
+	file.Close()
+	if _, xerr := p.Open(types.OpenCreateExclusive); xerr == nil || xerr.Code() != types.PathExistsError {
+		t.Errorf("expected exclusive create of existing file to fail with PathExistsError but got: %v", xerr)
+	}
+}
+
+func TestPath7(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "markers", "done"))
+	if xerr := p.Touch(); xerr != nil {
+		t.Fatalf("failed to create marker file: %v", xerr)
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if xerr := p.TouchAt(at); xerr != nil {
+		t.Fatalf("failed to update marker file: %v", xerr)
+	}
+	info, err := os.Stat(p.FSPath)
+	if err != nil {
+		t.Fatalf("failed to stat marker file: %v", err)
+	}
+	if !info.ModTime().Equal(at) || info.Size() != 0 {
+		t.Errorf("unexpected marker file: mtime=%s size=%d", info.ModTime(), info.Size())
+	}
+}
+
+func TestPath8(t *testing.T) {
+	fsys := types.ReadOnlyFS(fstest.MapFS{
+		"etc/app/config.json": &fstest.MapFile{Data: []byte(`{}`), Mode: 0644},
+	})
+	p := types.Path{FS: fsys, FSPath: "/etc/app/config.json"}
+	if !p.IsReadable() || p.IsWritable() || p.IsExecutable() {
+		t.Errorf("unexpected access for read-only file")
+	}
+
+	p = types.NewPath(filepath.Join(t.TempDir(), "secret"))
+	p.FileMode = 0600
+	if xerr := p.Touch(); xerr != nil {
+		t.Fatalf("failed to create file: %v", xerr)
+	}
+	p.Owner, p.Group = 12345, 12345
+	xerr := p.CheckOwnerAccess(types.AccessRead)
+	if xerr == nil || xerr.Code() != types.PathAccessDeniedError {
+		t.Errorf("expected read access for another owner to be denied but got: %v", xerr)
+	}
+	t.Logf("owner access: %v", xerr)
+}
+
+func TestPath9(t *testing.T) {
+	dir := types.NewPath(t.TempDir())
+	state, _ := dir.Join("state.json")
+	if xerr := state.WriteJSON(map[string]int{"count": 3}); xerr != nil {
+		t.Fatalf("failed to write JSON file: %v", xerr)
+	}
+	var decoded map[string]int
+	if xerr := state.ReadJSON(&decoded); xerr != nil || decoded["count"] != 3 {
+		t.Errorf("unexpected JSON contents: %v (%v)", decoded, xerr)
+	}
+
+	log, _ := dir.Join("run.log")
+	if xerr := log.WriteLines([]string{"started", "running"}, true); xerr != nil {
+		t.Fatalf("failed to write lines: %v", xerr)
+	}
+	if xerr := log.WriteString("stopped\n", false); xerr != nil {
+		t.Fatalf("failed to append string: %v", xerr)
+	}
+	data, _ := log.ReadFile()
+	if string(data) != "started\nrunning\nstopped\n" {
+		t.Errorf("unexpected log contents: %q", data)
+	}
+}
+
+func TestPath10(t *testing.T) {
+	p, xerr := types.NewPathTemplate("/var/log/app/{{hostname}}/{{date}}/run-{{pid}}-{{ uuid }}.log")
+	if xerr != nil {
+		t.Fatalf("failed to render path template: %v", xerr)
+	}
+	t.Logf("rendered path: %s", p.FSPath)
+	if _, xerr := types.NewPathTemplate("/tmp/{{bogus}}"); xerr == nil {
+		t.Errorf("expected unknown placeholder to fail")
+	}
+}
+
+func TestPath11(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "data.bin"))
+	if xerr := p.WriteString("mapped contents", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+	m, xerr := p.Mmap()
+	if xerr != nil {
+		t.Fatalf("failed to map file: %v", xerr)
+	}
+	defer m.Close()
+	if string(m.Bytes()) != "mapped contents" {
+		t.Errorf("unexpected mapped contents: %q", m.Bytes())
+	}
+}
+
+func TestPath12(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "journal"))
+	if xerr := p.Allocate(types.Size(64 * 1024)); xerr != nil {
+		t.Fatalf("failed to allocate file: %v", xerr)
+	}
+	if xerr := p.Truncate(types.Size(1024)); xerr != nil {
+		t.Fatalf("failed to truncate file: %v", xerr)
+	}
+	info, err := os.Stat(p.FSPath)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if info.Size() != 1024 {
+		t.Errorf("expected file size of 1024 but got %d", info.Size())
+	}
+}
+
+func TestPath13(t *testing.T) {
+	dir := t.TempDir()
+	p := types.NewPath(filepath.Join(dir, "data.txt"))
+	if xerr := p.Touch(); xerr != nil {
+		t.Fatalf("failed to create file: %v", xerr)
+	}
+	if xerr := types.NewPath(filepath.Join(dir, "link.txt")).Symlink(p.FSPath); xerr != nil {
+		t.Fatalf("failed to create symlink: %v", xerr)
+	}
+	for _, other := range []string{filepath.Join(dir, ".", "data.txt"), filepath.Join(dir, "link.txt")} {
+		same, xerr := p.Same(types.NewPath(other))
+		if xerr != nil {
+			t.Fatalf("failed to compare paths: %v", xerr)
+		}
+		if !same {
+			t.Errorf("expected %s to be the same as %s", other, p.FSPath)
+		}
+	}
+}
+
+func TestPath14(t *testing.T) {
+	dir := types.NewPath(filepath.Join(t.TempDir(), "state"))
+	dir.AutoChmod = true
+	dir.DirMode = 0700
+	actions, xerr := dir.EnsureDir()
+	if xerr != nil {
+		t.Fatalf("failed to ensure folder: %v", xerr)
+	}
+	if !actions.Has(types.EnsureCreated) {
+		t.Errorf("expected folder to be created but got: %s", actions)
+	}
+	if err := os.Chmod(dir.FSPath, 0755); err != nil {
+		t.Fatalf("failed to change permissions: %v", err)
+	}
+	if actions, xerr = dir.EnsureDir(); xerr != nil || actions != types.EnsureChmodded {
+		t.Errorf("expected permissions to be reconciled but got: %s (%v)", actions, xerr)
+	}
+	if _, xerr := dir.EnsureFile(); xerr == nil || xerr.Code() != types.PathTypeError {
+		t.Errorf("expected ensuring a folder as a file to fail with PathTypeError but got: %v", xerr)
+	}
+}
+
+func TestPath15(t *testing.T) {
+	dir := t.TempDir()
+	p := types.NewPath(filepath.Join(dir, "data.txt"))
+	if xerr := p.WriteString("precious", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+	if xerr := types.NewPath(filepath.Join(dir, "link.txt")).Symlink(p.FSPath); xerr != nil {
+		t.Fatalf("failed to create symlink: %v", xerr)
+	}
+	dotted := dir + string(filepath.Separator) + "." + string(filepath.Separator) + "data.txt"
+	for _, dest := range []string{p.FSPath, dotted, filepath.Join(dir, "link.txt")} {
+		xerr := p.Copy(types.NewPath(dest))
+		if xerr == nil || xerr.Code() != types.PathCopyError {
+			t.Errorf("expected copying onto %s to fail with PathCopyError but got: %v", dest, xerr)
+		}
+		t.Logf("copy onto %s: %v", dest, xerr)
+		xerr = p.Clone(types.NewPath(dest))
+		if xerr == nil || xerr.Code() != types.PathCopyError {
+			t.Errorf("expected cloning onto %s to fail with PathCopyError but got: %v", dest, xerr)
+		}
+	}
+	if data, _ := p.ReadFile(); string(data) != "precious" {
+		t.Errorf("expected source to be untouched but got %q", data)
+	}
+}
+
+func TestPath16(t *testing.T) {
+	dir := t.TempDir()
+	p := types.Path{
+		AutoCreateParent: true,
+		FS:               types.ReadOnlyFS(fstest.MapFS{}),
+		FSPath:           filepath.Join(dir, "sub", "file.txt"),
+	}
+	if xerr := p.WriteString("data", true); xerr == nil {
+		t.Errorf("expected write to read-only filesystem to fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "sub")); !os.IsNotExist(err) {
+		t.Errorf("expected parent folder not to be created on the host filesystem: %v", err)
+	}
+}
+
+func TestPath17(t *testing.T) {
+	root := t.TempDir()
+	outside := filepath.Join(t.TempDir(), "outside.txt")
+	if err := os.WriteFile(outside, []byte("outside"), 0600); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "inside.txt"), []byte("inside"), 0600); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	p := types.NewPath(root)
+	p.FollowSymlinks = true
+	p.FileMode = 0666
+	p.DirMode = 0755
+	if xerr := p.ChmodAll(types.RecursiveOptions{}); xerr != nil {
+		t.Fatalf("failed to change permissions: %v", xerr)
+	}
+	if info, _ := os.Stat(filepath.Join(root, "inside.txt")); info.Mode().Perm() != 0666 {
+		t.Errorf("expected file inside the tree to be changed but got %s", info.Mode())
+	}
+	if info, _ := os.Stat(outside); info.Mode().Perm() != 0600 {
+		t.Errorf("expected file outside the tree to be untouched but got %s", info.Mode())
+	}
+}
+
+func TestPath18(t *testing.T) {
+	dir := t.TempDir()
+	p := types.NewPath(filepath.Join(dir, "tool"))
+	if xerr := p.WriteString("#!/bin/sh\n", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+	if err := os.Chmod(p.FSPath, os.ModeSetuid|0755); err != nil {
+		t.Fatalf("failed to change permissions: %v", err)
+	}
+	if info, _ := os.Stat(p.FSPath); info.Mode()&os.ModeSetuid == 0 {
+		t.Skip("setuid bit is not supported here")
+	}
+
+	dest := types.Path{FSPath: filepath.Join(dir, "tool-copy")}
+	if xerr := p.Copy(dest); xerr != nil {
+		t.Fatalf("failed to copy file: %v", xerr)
+	}
+	info, err := os.Stat(dest.FSPath)
+	if err != nil {
+		t.Fatalf("failed to stat copy: %v", err)
+	}
+	if info.Mode()&os.ModeSetuid == 0 || info.Mode().Perm() != 0755 {
+		t.Errorf("expected setuid bit to be preserved but got %s", info.Mode())
+	}
+	t.Logf("copied mode: %s", info.Mode())
+}
+
+func TestPath19(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "settings.json"))
+	p.FileMode = 0640
+	if xerr := p.WriteFileAtomic([]byte("{}")); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+	info, err := os.Stat(p.FSPath)
+	if err != nil {
+		t.Fatalf("failed to stat file: %v", err)
+	}
+	if info.Mode().Perm() != 0640 {
+		t.Errorf("expected file to be created with mode 0640 but got %s", info.Mode())
+	}
+}
+
+func TestPath20(t *testing.T) {
+	dir := t.TempDir()
+	plain := types.NewPath(filepath.Join(dir, "plain.txt"))
+	if xerr := plain.WriteString("hello, world", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+
+	// plain to compressed compresses the contents
+	gz := types.NewPath(filepath.Join(dir, "data.txt.gz"))
+	gz.Compression = types.CompressionGzip
+	if xerr := plain.Copy(gz); xerr != nil {
+		t.Fatalf("failed to copy file: %v", xerr)
+	}
+	if data, _ := gz.ReadFile(); string(data) != "hello, world" {
+		t.Errorf("unexpected compressed contents: %q", data)
+	}
+
+	// matching formats copy the raw bytes
+	gzCopy := types.NewPath(filepath.Join(dir, "copy.txt.gz"))
+	gzCopy.Compression = types.CompressionGzip
+	if xerr := gz.Copy(gzCopy); xerr != nil {
+		t.Fatalf("failed to copy file: %v", xerr)
+	}
+	raw, _ := os.ReadFile(gz.FSPath)
+	rawCopy, _ := os.ReadFile(gzCopy.FSPath)
+	if string(raw) != string(rawCopy) {
+		t.Errorf("expected compressed files with the same format to be copied unchanged")
+	}
+
+	// compressed to plain decompresses the contents
+	out := types.NewPath(filepath.Join(dir, "out.txt"))
+	if xerr := gzCopy.Copy(out); xerr != nil {
+		t.Fatalf("failed to copy file: %v", xerr)
+	}
+	if data, _ := os.ReadFile(out.FSPath); string(data) != "hello, world" {
+		t.Errorf("unexpected decompressed contents: %q", data)
+	}
+}
+
+func TestPath21(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "file.txt"))
+	file, xerr := p.OpenFile(os.O_CREATE | os.O_WRONLY)
+	if xerr != nil {
+		t.Fatalf("failed to open file: %v", xerr)
+	}
+	t.Logf("file descriptor: %d", file.Fd())
+	file.Close()
+
+	p.Compression = types.CompressionGzip
+	if _, xerr := p.OpenFile(os.O_RDONLY); xerr == nil || xerr.Code() != types.PathOpenFileError {
+		t.Errorf("expected opening a compressed file to fail with PathOpenFileError but got: %v", xerr)
+	}
+
+	fsys := types.ReadOnlyFS(fstest.MapFS{
+		"config.json": &fstest.MapFile{Data: []byte(`{}`), Mode: 0644},
+	})
+	ro := types.Path{FS: fsys, FSPath: "config.json"}
+	if _, xerr := ro.OpenFile(os.O_RDONLY); xerr == nil || xerr.Code() != types.PathOpenFileError {
+		t.Errorf("expected opening a file on another filesystem to fail with PathOpenFileError but got: %v", xerr)
+	}
+	rofile, xerr := ro.OpenFS(os.O_RDONLY)
+	if xerr != nil {
+		t.Fatalf("failed to open file: %v", xerr)
+	}
+	rofile.Close()
+}
+
+func TestPath22(t *testing.T) {
+	fsys := types.ReadOnlyFS(fstest.MapFS{
+		"var/log/app.log": &fstest.MapFile{Data: []byte("first\nsecond\n"), Mode: 0644},
+	})
+	p := types.Path{FS: fsys, FSPath: "/var/log/app.log"}
+
+	if _, xerr := p.DiskFree(); xerr == nil || xerr.Code() != types.PathDiskUsageError {
+		t.Errorf("expected DiskFree to fail with PathDiskUsageError but got: %v", xerr)
+	}
+	if _, xerr := p.DiskTotal(); xerr == nil || xerr.Code() != types.PathDiskUsageError {
+		t.Errorf("expected DiskTotal to fail with PathDiskUsageError but got: %v", xerr)
+	}
+	if _, xerr := p.ResolveSymlinks(); xerr == nil || xerr.Code() != types.PathSymlinkError {
+		t.Errorf("expected ResolveSymlinks to fail with PathSymlinkError but got: %v", xerr)
+	}
+
+	// tailing reads from the configured filesystem rather than the host
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	lines, xerr := p.Tail(ctx, types.TailOptions{FromStart: true, Interval: types.Duration(10 * time.Millisecond)})
+	if xerr != nil {
+		t.Fatalf("failed to tail file: %v", xerr)
+	}
+	for _, want := range []string{"first", "second"} {
+		if line := <-lines; line.Text != want {
+			t.Errorf("expected line %q but got %q", want, line.Text)
+		}
+	}
+}
+
+func TestPath23(t *testing.T) {
+	for _, acl := range []bool{false, true} {
+		p := types.NewPath(filepath.Join(t.TempDir(), "file.txt"))
+		p.AutoChmod = true
+		p.FileMode = 0600
+		p.WindowsACL = acl
+		if xerr := p.WriteString("data", true); xerr != nil {
+			t.Fatalf("failed to write file: %v", xerr)
+		}
+		info, err := os.Stat(p.FSPath)
+		if err != nil {
+			t.Fatalf("failed to stat file: %v", err)
+		}
+		if info.Mode()&0200 == 0 {
+			t.Errorf("expected file to be writable by its owner but got %s", info.Mode())
+		}
+
+		p.FileMode = 0400
+		if xerr := p.Chmod(); xerr != nil {
+			t.Fatalf("failed to change permissions: %v", xerr)
+		}
+		if info, _ = os.Stat(p.FSPath); info.Mode()&0200 != 0 {
+			t.Errorf("expected file to be read-only but got %s", info.Mode())
+		}
+		t.Logf("mode with WindowsACL=%t: %s", acl, info.Mode())
+	}
+}
+
+func TestPath24(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(t.TempDir(), "target.txt")
+	if err := os.WriteFile(target, []byte("target"), 0600); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	link := types.NewPath(filepath.Join(dir, "link"))
+	link.AutoChmod = true
+	link.AutoChown = true
+	link.FollowSymlinks = true
+	link.FileMode = 0666
+	if xerr := link.Symlink(target); xerr != nil {
+		t.Fatalf("failed to create symlink: %v", xerr)
+	}
+	if info, _ := os.Stat(target); info.Mode().Perm() != 0600 {
+		t.Errorf("expected the target of the link to be untouched but got %s", info.Mode())
+	}
+	if dest, _ := link.Readlink(); dest != target {
+		t.Errorf("expected link to point to '%s' but got '%s'", target, dest)
+	}
+}
+
+func TestPath25(t *testing.T) {
+	// register a format which records whether its reader is closed
+	var closed int
+	types.RegisterCompression("test-identity",
+		func(r io.Reader) (io.ReadCloser, error) {
+			return closeFunc{Reader: r, close: func() error { closed++; return nil }}, nil
+		},
+		func(w io.Writer) (io.WriteCloser, error) {
+			return nopWriteCloser{w}, nil
+		})
+
+	p := types.NewPath(filepath.Join(t.TempDir(), "file.txt"))
+	p.Compression = "test-identity"
+	if xerr := p.WriteString("data", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+	data, xerr := p.ReadFileMax(types.Kilobyte)
+	if xerr != nil || string(data) != "data" {
+		t.Fatalf("expected to read 'data' but got '%s': %v", data, xerr)
+	}
+	if closed != 1 {
+		t.Errorf("expected the decompressing reader to be closed once but it was closed %d times", closed)
+	}
+}
+
+// closeFunc is an io.ReadCloser which calls the given function when it is closed.
+type closeFunc struct {
+	io.Reader
+	close func() error
+}
+
+func (c closeFunc) Close() error {
+	return c.close()
+}
+
+// nopWriteCloser is an io.WriteCloser which does nothing when it is closed.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func TestPath26(t *testing.T) {
+	// YAML files cannot be read or written until a YAML library is registered, even if the file does not exist
+	p := types.NewPath(filepath.Join(t.TempDir(), "config.yaml"))
+	config := map[string]any{"debug": true}
+	if xerr := p.WriteYAML(config); xerr == nil || xerr.Code() != types.PathYAMLNotRegisteredError {
+		t.Errorf("expected writing YAML without a registered library to fail but got: %v", xerr)
+	}
+	if xerr := p.ReadYAML(&config); xerr == nil || xerr.Code() != types.PathYAMLNotRegisteredError {
+		t.Errorf("expected reading YAML without a registered library to fail but got: %v", xerr)
+	}
+	if _, err := os.Stat(p.FSPath); err == nil {
+		t.Errorf("expected no file to be written")
+	}
+
+	// JSON is a subset of YAML, so it can stand in for a YAML library
+	types.RegisterYAML(json.Marshal, json.Unmarshal)
+	defer types.RegisterYAML(nil, nil)
+	if xerr := p.WriteYAML(config); xerr != nil {
+		t.Fatalf("failed to write YAML file: %v", xerr)
+	}
+	var read map[string]any
+	if xerr := p.ReadYAML(&read); xerr != nil || read["debug"] != true {
+		t.Errorf("expected to read back %v but got %v: %v", config, read, xerr)
+	}
+}
+
+// otherFS is the operating system's filesystem under a different type, so that paths using it are treated as being
+// on a different filesystem from paths using [types.OSFS].
+type otherFS struct {
+	types.OSFS
+}
+
+func TestPath27(t *testing.T) {
+	dir := t.TempDir()
+	newTree := func(name string) types.Path {
+		root := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Join(root, "sub"), 0750); err != nil {
+			t.Fatalf("failed to create folder: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "sub", "file.txt"), []byte("data"), 0600); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		if err := os.Symlink("sub/file.txt", filepath.Join(root, "link")); err != nil {
+			t.Fatalf("failed to create link: %v", err)
+		}
+		return types.NewPath(root)
+	}
+	checkTree := func(src, dest types.Path) {
+		t.Helper()
+		if _, err := os.Lstat(src.FSPath); !os.IsNotExist(err) {
+			t.Errorf("expected '%s' to be removed but got: %v", src.FSPath, err)
+		}
+		if data, err := os.ReadFile(filepath.Join(dest.FSPath, "sub", "file.txt")); string(data) != "data" {
+			t.Errorf("expected file to be moved but got '%s': %v", data, err)
+		}
+		if info, err := os.Stat(filepath.Join(dest.FSPath, "sub", "file.txt")); err != nil ||
+			info.Mode().Perm() != 0600 {
+			t.Errorf("expected file permissions to be preserved but got %v: %v", info.Mode(), err)
+		}
+		if link, err := os.Readlink(filepath.Join(dest.FSPath, "link")); link != "sub/file.txt" {
+			t.Errorf("expected link to be moved but got '%s': %v", link, err)
+		}
+	}
+
+	// directories on the same filesystem are renamed
+	src, dest := newTree("rename"), types.NewPath(filepath.Join(dir, "renamed"))
+	if xerr := src.Move(dest); xerr != nil {
+		t.Fatalf("failed to move folder: %v", xerr)
+	}
+	checkTree(src, dest)
+
+	// directories on another filesystem are copied and then removed
+	src, dest = newTree("copy"), types.NewPath(filepath.Join(dir, "copied"))
+	dest.FS = otherFS{}
+	if xerr := src.Move(dest); xerr != nil {
+		t.Fatalf("failed to move folder to another filesystem: %v", xerr)
+	}
+	checkTree(src, dest)
+
+	// files on another filesystem are copied and then removed
+	file := types.NewPath(filepath.Join(dest.FSPath, "sub", "file.txt"))
+	file.FS = otherFS{}
+	fileDest := types.NewPath(filepath.Join(dir, "file.txt"))
+	if xerr := file.Move(fileDest); xerr != nil {
+		t.Fatalf("failed to move file to another filesystem: %v", xerr)
+	}
+	if data, _ := os.ReadFile(fileDest.FSPath); string(data) != "data" {
+		t.Errorf("expected file to be moved but got '%s'", data)
+	}
+	if _, err := os.Stat(file.FSPath); !os.IsNotExist(err) {
+		t.Errorf("expected '%s' to be removed but got: %v", file.FSPath, err)
+	}
+
+	// directories are never copied over an existing destination
+	src = newTree("existing")
+	if xerr := src.Move(dest); xerr == nil || xerr.Code() != types.PathMoveError {
+		t.Errorf("expected moving onto an existing folder to fail but got: %v", xerr)
+	}
+	if _, err := os.Stat(filepath.Join(src.FSPath, "sub", "file.txt")); err != nil {
+		t.Errorf("expected the source to be left untouched but got: %v", err)
+	}
+}
+
+func TestPath28(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "root")
+	outside := filepath.Join(dir, "outside")
+	for _, name := range []string{filepath.Join(root, "sub"), outside} {
+		if err := os.MkdirAll(name, 0755); err != nil {
+			t.Fatalf("failed to create folder: %v", err)
+		}
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatalf("failed to create link: %v", err)
+	}
+
+	// the filesystem root is never removed, checked on a read-only filesystem in case the guard fails
+	fsRoot := filepath.VolumeName(dir) + string(filepath.Separator)
+	p := types.Path{FS: types.ReadOnlyFS(fstest.MapFS{}), FSPath: fsRoot}
+	if xerr := p.RemoveAll(fsRoot); xerr == nil || errors.Is(xerr, fs.ErrPermission) {
+		t.Errorf("expected removing the filesystem root to be refused but got: %v", xerr)
+	}
+
+	// the home directory is never removed, even from within a root which contains it
+	home := filepath.Join(dir, "home")
+	if err := os.Mkdir(home, 0755); err != nil {
+		t.Fatalf("failed to create folder: %v", err)
+	}
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	if xerr := types.NewPath(home).RemoveAll(dir); xerr == nil || xerr.Code() != types.PathDeleteError {
+		t.Errorf("expected removing the home directory to be refused but got: %v", xerr)
+	}
+
+	// paths which escape the root are refused, whether through ".." or a symbolic link in a parent folder
+	for _, name := range []string{
+		filepath.Join(root, "..", "outside"),
+		filepath.Join(root, "link", "victim"),
+	} {
+		if err := os.WriteFile(filepath.Join(outside, "victim"), []byte("data"), 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+		if xerr := types.NewPath(name).RemoveAll(root); xerr == nil || xerr.Code() != types.PathDeleteError {
+			t.Errorf("expected removing '%s' to be refused but got: %v", name, xerr)
+		}
+	}
+	for _, name := range []string{home, filepath.Join(outside, "victim")} {
+		if _, err := os.Stat(name); err != nil {
+			t.Errorf("expected '%s' to be left untouched but got: %v", name, err)
+		}
+	}
+
+	// paths within the root are removed, and removing a link leaves its target alone
+	for _, name := range []string{filepath.Join(root, "link"), filepath.Join(root, "sub")} {
+		if xerr := types.NewPath(name).RemoveAll(root); xerr != nil {
+			t.Errorf("failed to remove '%s': %v", name, xerr)
+		}
+		if _, err := os.Lstat(name); !os.IsNotExist(err) {
+			t.Errorf("expected '%s' to be removed but got: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(outside, "victim")); err != nil {
+		t.Errorf("expected the target of the link to be left untouched but got: %v", err)
+	}
+}
+
+// ownershipFS is the operating system's filesystem, except that it records the permissions and ownership applied to
+// each path rather than changing the ownership.
+type ownershipFS struct {
+	types.OSFS
+	modes  map[string]os.FileMode
+	owners map[string][2]int
+}
+
+func (f ownershipFS) Chmod(name string, mode os.FileMode) error {
+	f.modes[name] = mode
+	return f.OSFS.Chmod(name, mode)
+}
+
+func (f ownershipFS) Chown(name string, uid, gid int) error {
+	f.owners[name] = [2]int{uid, gid}
+	return nil
+}
+
+func (f ownershipFS) Lchown(name string, uid, gid int) error {
+	return f.Chown(name, uid, gid)
+}
+
+func TestPath29(t *testing.T) {
+	fsys := ownershipFS{modes: map[string]os.FileMode{}, owners: map[string][2]int{}}
+	dir := types.NewPath(filepath.Join(t.TempDir(), "tmp"))
+	dir.FS = fsys
+	dir.AutoChown = true
+	dir.Owner = 1234
+	dir.Group = 5678
+	dir.DirMode = 0700
+	dir.FileMode = 0600
+
+	// the folder is created first and the permissions and ownership are always applied to what is created in it
+	tmpDir, xerr := dir.TempDir("dir-*")
+	if xerr != nil {
+		t.Fatalf("failed to create temporary directory: %v", xerr)
+	}
+	tmpFile, xerr := dir.TempFile("file-*.txt")
+	if xerr != nil {
+		t.Fatalf("failed to create temporary file: %v", xerr)
+	}
+	for _, tc := range []struct {
+		path    types.Path
+		pattern string
+		mode    os.FileMode
+		isDir   bool
+	}{
+		{tmpDir, "dir-*", 0700, true},
+		{tmpFile, "file-*.txt", 0600, false},
+	} {
+		name := tc.path.FSPath
+		if ok, _ := filepath.Match(filepath.Join(dir.FSPath, tc.pattern), name); !ok {
+			t.Errorf("expected '%s' to match '%s' within '%s'", name, tc.pattern, dir.FSPath)
+		}
+		if info, err := os.Stat(name); err != nil || info.IsDir() != tc.isDir || (!tc.isDir && info.Size() != 0) {
+			t.Errorf("expected '%s' to be created empty but got %v: %v", name, info, err)
+		}
+		if mode := fsys.modes[name]; mode.Perm() != tc.mode {
+			t.Errorf("expected permissions of '%s' to be set to %s but got %s", name, tc.mode, mode)
+		}
+		if owner := fsys.owners[name]; owner != [2]int{1234, 5678} {
+			t.Errorf("expected ownership of '%s' to be set to 1234:5678 but got %v", name, owner)
+		}
+	}
+}
+
+func TestPath30(t *testing.T) {
+	dir := t.TempDir()
+	src := types.NewPath(filepath.Join(dir, "file.txt"))
+	if xerr := src.WriteString("data", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+
+	// both paths refer to the same file once linked
+	dest := types.NewPath(filepath.Join(dir, "sub", "link.txt"))
+	if xerr := src.Link(dest); xerr != nil {
+		t.Fatalf("failed to create link: %v", xerr)
+	}
+	if same, xerr := src.Same(dest); xerr != nil || !same {
+		t.Errorf("expected the link to be the same file as the source: %v", xerr)
+	}
+	if xerr := dest.WriteString("changed", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+	if data, _ := os.ReadFile(src.FSPath); string(data) != "changed" {
+		t.Errorf("expected changes through the link to be seen in the source but got '%s'", data)
+	}
+
+	// a copy is not the same file, and links are never created over an existing file
+	copied := types.NewPath(filepath.Join(dir, "copy.txt"))
+	if xerr := src.Copy(copied); xerr != nil {
+		t.Fatalf("failed to copy file: %v", xerr)
+	}
+	if same, _ := src.Same(copied); same {
+		t.Errorf("expected a copy not to be the same file as the source")
+	}
+	if xerr := src.Link(copied); xerr == nil || xerr.Code() != types.PathLinkError {
+		t.Errorf("expected linking over an existing file to fail but got: %v", xerr)
+	}
+}
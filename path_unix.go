@@ -0,0 +1,42 @@
+//go:build darwin || freebsd || linux
+
+package types
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// diskSpace returns the number of bytes available to unprivileged users and the total number of bytes on the
+// filesystem containing the given path.
+func diskSpace(path string) (uint64, uint64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, 0, err
+	}
+	return uint64(st.Bavail) * uint64(st.Bsize), uint64(st.Blocks) * uint64(st.Bsize), nil
+}
+
+// access checks whether the current process may access the given path using access(2).
+func access(path string, mode Access) error {
+	return syscall.Access(path, uint32(mode))
+}
+
+// fileOwner returns the numeric uid and gid of the file.
+func fileOwner(info fs.FileInfo) (int, int, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(st.Uid), int(st.Gid), true
+}
+
+// mmapFile maps the first size bytes of the file into memory for read-only access.
+func mmapFile(file *os.File, size int) ([]byte, func() error, error) {
+	data, err := syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,260 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.innotegrity.dev/xerrors"
+)
+
+// defaultWatchInterval is the default interval at which a watched path is polled for changes.
+const defaultWatchInterval = Duration(time.Second)
+
+var (
+	// watcher is the function used to receive notifications about changes to the operating system's filesystem.
+	watcher WatchFunc
+
+	// watcherMu guards access to watcher.
+	watcherMu sync.RWMutex
+)
+
+// PathEventOp describes the type of change which occurred to a watched path.
+type PathEventOp int
+
+const (
+	// PathCreated indicates the path was created.
+	PathCreated PathEventOp = iota + 1
+
+	// PathModified indicates the contents, size or permissions of the path changed.
+	PathModified
+
+	// PathDeleted indicates the path was removed.
+	PathDeleted
+
+	// PathRenamed indicates the path was renamed or replaced by a different file, such as when a file is renamed over
+	// it.
+	PathRenamed
+)
+
+// String returns the [PathEventOp] object as a string.
+func (o PathEventOp) String() string {
+	switch o {
+	case PathCreated:
+		return "create"
+	case PathModified:
+		return "modify"
+	case PathDeleted:
+		return "delete"
+	case PathRenamed:
+		return "rename"
+	}
+	return fmt.Sprintf("PathEventOp(%d)", int(o))
+}
+
+// PathEvent describes a change which occurred to a watched path.
+type PathEvent struct {
+	// Op is the type of change which occurred.
+	Op PathEventOp
+
+	// Path is the path which changed.
+	Path Path
+
+	// Time is the time at which the change was detected.
+	Time time.Time
+}
+
+// WatchFunc watches the given directory for changes using a filesystem notification library and calls notify with
+// the full name of the changed entry and the type of change each time one of its entries changes.
+//
+// The function must return once watching has started and must stop watching when ctx is cancelled. See
+// [RegisterWatcher] for details.
+type WatchFunc func(ctx context.Context, dir string, notify func(name string, op PathEventOp)) error
+
+// WatchOptions holds the settings used when watching a path for changes.
+type WatchOptions struct {
+	// Debounce is the amount of time to wait for further changes before an event is sent.
+	//
+	// When multiple changes occur within this window, only the last one is sent. If 0, every change is sent as
+	// soon as it is detected.
+	Debounce Duration `json:"debounce" yaml:"debounce" mapstructure:"debounce"`
+
+	// Interval is how often the path is checked for changes when it is polled. If 0, the path is checked every
+	// second. It is not used when the path is watched using a function registered with [RegisterWatcher].
+	Interval Duration `json:"interval" yaml:"interval" mapstructure:"interval"`
+}
+
+// RegisterWatcher registers the function used by [Path.Watch] to receive notifications about changes to files on the
+// operating system's filesystem, or removes it if fn is nil.
+//
+// Filesystem notifications are not supported by the standard library, so paths are polled for changes unless a
+// notification library has been registered. For example, to use fsnotify:
+//
+//	types.RegisterWatcher(func(ctx context.Context, dir string, notify func(string, types.PathEventOp)) error {
+//		w, err := fsnotify.NewWatcher()
+//		if err != nil {
+//			return err
+//		}
+//		if err := w.Add(dir); err != nil {
+//			w.Close()
+//			return err
+//		}
+//		go func() {
+//			defer w.Close()
+//			for {
+//				select {
+//				case <-ctx.Done():
+//					return
+//				case e := <-w.Events:
+//					switch {
+//					case e.Has(fsnotify.Create):
+//						notify(e.Name, types.PathCreated)
+//					case e.Has(fsnotify.Remove):
+//						notify(e.Name, types.PathDeleted)
+//					case e.Has(fsnotify.Rename):
+//						notify(e.Name, types.PathRenamed)
+//					default:
+//						notify(e.Name, types.PathModified)
+//					}
+//				case <-w.Errors:
+//				}
+//			}
+//		}()
+//		return nil
+//	})
+func RegisterWatcher(fn WatchFunc) {
+	watcherMu.Lock()
+	defer watcherMu.Unlock()
+	watcher = fn
+}
+
+// Watch watches the path for changes and sends an event on the returned channel whenever one is detected.
+//
+// Paths which do not exist yet can be watched. Watching stops and the channel is closed when ctx is cancelled.
+//
+// If a notification library has been registered with [RegisterWatcher] and the path is on the operating system's
+// filesystem, the folder containing the path is watched using it so that every change is reported, including when
+// the file is renamed or replaced by an editor. Otherwise the path is polled at the interval given in opts using
+// [Path.FS], which has the following limitations:
+//
+//   - changes which are undone between two checks, such as a file which is created and then deleted, are not seen
+//   - a file which is renamed away is reported as [PathDeleted] and a file which is renamed over the path is reported
+//     as [PathRenamed], but only on the operating system's filesystem since no other exposes the identity of a file
+//
+// This function may return an error with any of the following codes:
+//   - [PathError]: there was a general error while working with the path or the notification library
+func (p Path) Watch(ctx context.Context, opts WatchOptions) (<-chan PathEvent, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	fsys := p.fs()
+	last, err := fsys.Stat(p.FSPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, xerrors.Wrapf(PathError, err, "failed to watch '%s': %s", p.FSPath, err.Error()).
+			WithAttr("path", p.FSPath)
+	}
+
+	// use the registered notification library if there is one, otherwise poll for changes
+	watcherMu.RLock()
+	watch := watcher
+	watcherMu.RUnlock()
+	ctx, cancel := context.WithCancel(ctx)
+	var changes chan PathEvent
+	var ticker *time.Ticker
+	var ticks <-chan time.Time
+	if watch != nil && isOSFS(fsys) {
+		changes = make(chan PathEvent)
+		name := filepath.Clean(p.FSPath)
+		notify := func(changed string, op PathEventOp) {
+			if filepath.Clean(changed) != name {
+				return
+			}
+			select {
+			case changes <- PathEvent{Op: op, Path: p, Time: time.Now()}:
+			case <-ctx.Done():
+			}
+		}
+		if err := watch(ctx, filepath.Dir(name), notify); err != nil {
+			cancel()
+			return nil, xerrors.Wrapf(PathError, err, "failed to watch '%s': %s", p.FSPath, err.Error()).
+				WithAttr("path", p.FSPath)
+		}
+	} else {
+		ticker = time.NewTicker(time.Duration(interval))
+		ticks = ticker.C
+	}
+
+	events := make(chan PathEvent)
+	go func() {
+		defer close(events)
+		defer cancel()
+		if ticker != nil {
+			defer ticker.Stop()
+		}
+		debounce := time.NewTimer(0)
+		defer debounce.Stop()
+		<-debounce.C
+
+		var pending *PathEvent
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticks:
+				current, _ := fsys.Stat(p.FSPath)
+				op := compareFileInfo(fsys, last, current)
+				last = current
+				if op == 0 {
+					continue
+				}
+				pending = &PathEvent{Op: op, Path: p, Time: now}
+			case event := <-changes:
+				pending = &event
+			case <-debounce.C:
+			}
+
+			// wait for further changes before sending the event
+			if pending == nil {
+				continue
+			}
+			if opts.Debounce > 0 && !time.Now().After(pending.Time.Add(time.Duration(opts.Debounce))) {
+				debounce.Reset(time.Until(pending.Time.Add(time.Duration(opts.Debounce))) + time.Millisecond)
+				continue
+			}
+			select {
+			case events <- *pending:
+				pending = nil
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// compareFileInfo returns the type of change between the two states of a file or 0 if nothing changed.
+//
+// A nil value indicates that the file did not exist.
+func compareFileInfo(fsys FS, before, after fs.FileInfo) PathEventOp {
+	switch {
+	case before == nil && after == nil:
+		return 0
+	case before == nil:
+		return PathCreated
+	case after == nil:
+		return PathDeleted
+	case !sameFile(fsys, before, after):
+		return PathRenamed
+	case !before.ModTime().Equal(after.ModTime()) || before.Size() != after.Size() || before.Mode() != after.Mode():
+		return PathModified
+	}
+	return 0
+}
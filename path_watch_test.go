@@ -0,0 +1,143 @@
+package types_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+// nextPathEvent waits for the next event from the channel, failing the test if none arrives in time.
+func nextPathEvent(t *testing.T, events <-chan types.PathEvent) types.PathEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatalf("expected an event but the channel was closed")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for an event")
+	}
+	return types.PathEvent{}
+}
+
+func TestPathWatch1(t *testing.T) {
+	dir := t.TempDir()
+	p := types.NewPath(filepath.Join(dir, "config.json"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, xerr := p.Watch(ctx, types.WatchOptions{Interval: types.Duration(10 * time.Millisecond)})
+	if xerr != nil {
+		t.Fatalf("failed to watch path: %v", xerr)
+	}
+
+	steps := []struct {
+		op     types.PathEventOp
+		change func() error
+	}{
+		{types.PathCreated, func() error { return os.WriteFile(p.FSPath, []byte("{}"), 0644) }},
+		{types.PathModified, func() error { return os.WriteFile(p.FSPath, []byte(`{"debug": true}`), 0644) }},
+		{types.PathRenamed, func() error {
+			// replace the file in the same way as an editor saving it
+			tmp := filepath.Join(dir, "config.json.tmp")
+			if err := os.WriteFile(tmp, []byte(`{"debug": false}`), 0644); err != nil {
+				return err
+			}
+			return os.Rename(tmp, p.FSPath)
+		}},
+		{types.PathDeleted, func() error { return os.Remove(p.FSPath) }},
+	}
+	for _, step := range steps {
+		if err := step.change(); err != nil {
+			t.Fatalf("failed to change file: %v", err)
+		}
+		if event := nextPathEvent(t, events); event.Op != step.op || event.Path.FSPath != p.FSPath {
+			t.Errorf("expected %s event but got %s for '%s'", step.op, event.Op, event.Path.FSPath)
+		}
+	}
+
+	// the channel is closed once the context is cancelled
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Errorf("expected no more events after cancelling")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("timed out waiting for the channel to be closed")
+	}
+}
+
+func TestPathWatch2(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "config.json"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, xerr := p.Watch(ctx, types.WatchOptions{
+		Debounce: types.Duration(200 * time.Millisecond),
+		Interval: types.Duration(10 * time.Millisecond),
+	})
+	if xerr != nil {
+		t.Fatalf("failed to watch path: %v", xerr)
+	}
+
+	// several changes in quick succession only produce a single event
+	for i := 1; i <= 3; i++ {
+		if err := os.WriteFile(p.FSPath, make([]byte, i), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+	if event := nextPathEvent(t, events); event.Op != types.PathModified {
+		t.Errorf("expected the last change to be sent but got %s", event.Op)
+	}
+	select {
+	case event := <-events:
+		t.Errorf("expected a single event but also got %s", event.Op)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
+
+func TestPathWatch3(t *testing.T) {
+	var watchCtx context.Context
+	var watchDir string
+	var notify func(string, types.PathEventOp)
+	types.RegisterWatcher(func(ctx context.Context, dir string, fn func(string, types.PathEventOp)) error {
+		watchCtx, watchDir, notify = ctx, dir, fn
+		return nil
+	})
+	defer types.RegisterWatcher(nil)
+
+	dir := t.TempDir()
+	p := types.NewPath(filepath.Join(dir, "config.json"))
+	ctx, cancel := context.WithCancel(context.Background())
+	events, xerr := p.Watch(ctx, types.WatchOptions{})
+	if xerr != nil {
+		t.Fatalf("failed to watch path: %v", xerr)
+	}
+	if watchDir != dir {
+		t.Errorf("expected folder '%s' to be watched but got '%s'", dir, watchDir)
+	}
+
+	// changes to other files in the folder are ignored
+	go func() {
+		notify(filepath.Join(dir, "other.json"), types.PathModified)
+		notify(filepath.Join(dir, "config.json"), types.PathRenamed)
+	}()
+	if event := nextPathEvent(t, events); event.Op != types.PathRenamed {
+		t.Errorf("expected %s event but got %s", types.PathRenamed, event.Op)
+	}
+
+	// the registered watcher is stopped once the context is cancelled
+	cancel()
+	select {
+	case <-watchCtx.Done():
+	case <-time.After(5 * time.Second):
+		t.Errorf("timed out waiting for the watcher to be stopped")
+	}
+	for range events {
+	}
+}
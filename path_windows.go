@@ -0,0 +1,288 @@
+//go:build windows
+
+package types
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// seFileObject is the SE_FILE_OBJECT object type.
+	seFileObject = 1
+
+	// ownerSecurityInformation is the OWNER_SECURITY_INFORMATION flag.
+	ownerSecurityInformation = 0x00000001
+
+	// groupSecurityInformation is the GROUP_SECURITY_INFORMATION flag.
+	groupSecurityInformation = 0x00000002
+
+	// daclSecurityInformation is the DACL_SECURITY_INFORMATION flag.
+	daclSecurityInformation = 0x00000004
+
+	// protectedDACLSecurityInformation is the PROTECTED_DACL_SECURITY_INFORMATION flag.
+	protectedDACLSecurityInformation = 0x80000000
+
+	// setAccess is the SET_ACCESS access mode.
+	setAccess = 2
+
+	// subContainersAndObjectsInherit is the SUB_CONTAINERS_AND_OBJECTS_INHERIT inheritance flag.
+	subContainersAndObjectsInherit = 0x3
+
+	// trusteeIsSID is the TRUSTEE_IS_SID trustee form.
+	trusteeIsSID = 0
+
+	// fileGenericRead is the FILE_GENERIC_READ access right.
+	fileGenericRead = 0x00120089
+
+	// fileGenericWrite is the FILE_GENERIC_WRITE access right.
+	fileGenericWrite = 0x00120116
+
+	// fileGenericExecute is the FILE_GENERIC_EXECUTE access right.
+	fileGenericExecute = 0x001200a0
+
+	// everyoneSID is the well-known SID for the Everyone group.
+	everyoneSID = "S-1-1-0"
+)
+
+var (
+	// advapi32 is the advapi32.dll Windows library.
+	advapi32 = syscall.NewLazyDLL("advapi32.dll")
+
+	// kernel32 is the kernel32.dll Windows library.
+	kernel32 = syscall.NewLazyDLL("kernel32.dll")
+
+	// procGetDiskFreeSpaceEx is the GetDiskFreeSpaceExW Windows API function.
+	procGetDiskFreeSpaceEx = kernel32.NewProc("GetDiskFreeSpaceExW")
+
+	// procGetNamedSecurityInfo is the GetNamedSecurityInfoW Windows API function.
+	procGetNamedSecurityInfo = advapi32.NewProc("GetNamedSecurityInfoW")
+
+	// procLocalFree is the LocalFree Windows API function.
+	procLocalFree = kernel32.NewProc("LocalFree")
+
+	// procSetEntriesInACL is the SetEntriesInAclW Windows API function.
+	procSetEntriesInACL = advapi32.NewProc("SetEntriesInAclW")
+
+	// procSetNamedSecurityInfo is the SetNamedSecurityInfoW Windows API function.
+	procSetNamedSecurityInfo = advapi32.NewProc("SetNamedSecurityInfoW")
+)
+
+// trustee is the Windows TRUSTEE_W structure.
+type trustee struct {
+	multipleTrustee          *trustee
+	multipleTrusteeOperation int32
+	trusteeForm              int32
+	trusteeType              int32
+	name                     *syscall.SID
+}
+
+// explicitAccess is the Windows EXPLICIT_ACCESS_W structure.
+type explicitAccess struct {
+	accessPermissions uint32
+	accessMode        uint32
+	inheritance       uint32
+	trustee           trustee
+}
+
+// access checks whether the current process may access the given path.
+//
+// Read access is checked by opening the path, write access by checking the read-only attribute and execute access by
+// checking the file's extension against the PATHEXT environment variable.
+func access(path string, mode Access) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if mode&AccessRead != 0 {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		f.Close()
+	}
+	if mode&AccessWrite != 0 && info.Mode().Perm()&0200 == 0 {
+		return fs.ErrPermission
+	}
+	if mode&AccessExecute != 0 && !info.IsDir() {
+		pathExt := os.Getenv("PATHEXT")
+		if pathExt == "" {
+			pathExt = ".com;.exe;.bat;.cmd"
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if ext == "" || !slices.Contains(strings.Split(strings.ToLower(pathExt), ";"), ext) {
+			return fs.ErrPermission
+		}
+	}
+	return nil
+}
+
+// chown changes the ownership of the path to the configured Windows owner and group.
+//
+// If the path is not on the operating system's filesystem, the [Path.Owner] and [Path.Group] values are passed to the
+// filesystem instead.
+func (p Path) chown() error {
+	if fsys := p.fs(); !isOSFS(fsys) {
+		if p.FollowSymlinks {
+			return fsys.Chown(p.FSPath, int(p.Owner), int(p.Group))
+		}
+		return fsys.Lchown(p.FSPath, int(p.Owner), int(p.Group))
+	}
+	if p.WindowsOwner == "" && p.WindowsGroup == "" {
+		return nil
+	}
+	var info uintptr
+	var owner, group *syscall.SID
+	var err error
+	if p.WindowsOwner != "" {
+		if owner, err = lookupSID(p.WindowsOwner); err != nil {
+			return err
+		}
+		info |= ownerSecurityInformation
+	}
+	if p.WindowsGroup != "" {
+		if group, err = lookupSID(p.WindowsGroup); err != nil {
+			return err
+		}
+		info |= groupSecurityInformation
+	}
+	path, err := syscall.UTF16PtrFromString(p.FSPath)
+	if err != nil {
+		return err
+	}
+	r, _, _ := procSetNamedSecurityInfo.Call(uintptr(unsafe.Pointer(path)), seFileObject, info,
+		uintptr(unsafe.Pointer(owner)), uintptr(unsafe.Pointer(group)), 0, 0)
+	if r != 0 {
+		return syscall.Errno(r)
+	}
+	return nil
+}
+
+// diskSpace returns the number of bytes available to the current user and the total number of bytes on the volume
+// containing the given path.
+func diskSpace(path string) (uint64, uint64, error) {
+	p, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	var free, total uint64
+	r, _, err := procGetDiskFreeSpaceEx.Call(uintptr(unsafe.Pointer(p)), uintptr(unsafe.Pointer(&free)),
+		uintptr(unsafe.Pointer(&total)), 0)
+	if r == 0 {
+		return 0, 0, err
+	}
+	return free, total, nil
+}
+
+// fileOwner is not supported on Windows since files are owned by SIDs rather than numeric IDs.
+func fileOwner(info fs.FileInfo) (int, int, bool) {
+	return 0, 0, false
+}
+
+// lookupSID returns the SID for the given account name or SID string.
+func lookupSID(account string) (*syscall.SID, error) {
+	if strings.HasPrefix(strings.ToUpper(account), "S-1-") {
+		return syscall.StringToSid(account)
+	}
+	sid, _, _, err := syscall.LookupSID("", account)
+	return sid, err
+}
+
+// mmapFile maps the first size bytes of the file into memory for read-only access.
+func mmapFile(file *os.File, size int) ([]byte, func() error, error) {
+	h, err := syscall.CreateFileMapping(syscall.Handle(file.Fd()), nil, syscall.PAGE_READONLY, 0, 0, nil)
+	if err != nil {
+		return nil, nil, os.NewSyscallError("CreateFileMapping", err)
+	}
+	addr, err := syscall.MapViewOfFile(h, syscall.FILE_MAP_READ, 0, 0, uintptr(size))
+	syscall.CloseHandle(h)
+	if err != nil {
+		return nil, nil, os.NewSyscallError("MapViewOfFile", err)
+	}
+	data := unsafe.Slice((*byte)(*(*unsafe.Pointer)(unsafe.Pointer(&addr))), size)
+	return data, func() error { return syscall.UnmapViewOfFile(addr) }, nil
+}
+
+// newExplicitAccess returns an access entry granting the given SID the rights equivalent to the rwx permission bits.
+func newExplicitAccess(sid *syscall.SID, perm uint32, inheritance uint32) explicitAccess {
+	var rights uint32
+	if perm&4 != 0 {
+		rights |= fileGenericRead
+	}
+	if perm&2 != 0 {
+		rights |= fileGenericWrite
+	}
+	if perm&1 != 0 {
+		rights |= fileGenericExecute
+	}
+	return explicitAccess{
+		accessPermissions: rights,
+		accessMode:        setAccess,
+		inheritance:       inheritance,
+		trustee: trustee{
+			trusteeForm: trusteeIsSID,
+			name:        sid,
+		},
+	}
+}
+
+// setACL replaces the access control list of the given file or directory with entries granting the owner, the group
+// and everyone else the access described by the mode.
+//
+// The new list is protected, so any entries inherited from the parent folder are removed.
+func setACL(name string, mode os.FileMode) error {
+	info, err := os.Stat(name)
+	if err != nil {
+		return err
+	}
+
+	// lookup the current owner and group of the path
+	path, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return err
+	}
+	var owner, group *syscall.SID
+	var sd uintptr
+	r, _, _ := procGetNamedSecurityInfo.Call(uintptr(unsafe.Pointer(path)), seFileObject,
+		ownerSecurityInformation|groupSecurityInformation, uintptr(unsafe.Pointer(&owner)),
+		uintptr(unsafe.Pointer(&group)), 0, 0, uintptr(unsafe.Pointer(&sd)))
+	if r != 0 {
+		return syscall.Errno(r)
+	}
+	defer procLocalFree.Call(sd)
+	everyone, err := syscall.StringToSid(everyoneSID)
+	if err != nil {
+		return err
+	}
+
+	// build the new access control list
+	var inheritance uint32
+	if info.IsDir() {
+		inheritance = subContainersAndObjectsInherit
+	}
+	entries := []explicitAccess{
+		newExplicitAccess(owner, uint32(mode.Perm()>>6)&7, inheritance),
+		newExplicitAccess(group, uint32(mode.Perm()>>3)&7, inheritance),
+		newExplicitAccess(everyone, uint32(mode.Perm())&7, inheritance),
+	}
+	var acl uintptr
+	r, _, _ = procSetEntriesInACL.Call(uintptr(len(entries)), uintptr(unsafe.Pointer(&entries[0])), 0,
+		uintptr(unsafe.Pointer(&acl)))
+	if r != 0 {
+		return syscall.Errno(r)
+	}
+	defer procLocalFree.Call(acl)
+
+	// apply it to the path
+	r, _, _ = procSetNamedSecurityInfo.Call(uintptr(unsafe.Pointer(path)), seFileObject,
+		daclSecurityInformation|protectedDACLSecurityInformation, 0, 0, acl, 0)
+	if r != 0 {
+		return syscall.Errno(r)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+package types_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestPathWindows1(t *testing.T) {
+	// icacls marks entries inherited from the parent folder with "(I)"
+	inherited := func(name string) bool {
+		out, err := exec.Command("icacls", name).CombinedOutput()
+		if err != nil {
+			t.Fatalf("failed to list access control entries: %v: %s", err, out)
+		}
+		t.Logf("access control list of %s:\n%s", name, out)
+		return strings.Contains(string(out), "(I)")
+	}
+
+	p := types.NewPath(filepath.Join(t.TempDir(), "file.txt"))
+	p.AutoChmod = true
+	if xerr := p.WriteString("data", true); xerr != nil {
+		t.Fatalf("failed to write file: %v", xerr)
+	}
+	if !inherited(p.FSPath) {
+		t.Errorf("expected inherited entries to be kept when WindowsACL is false")
+	}
+
+	p.WindowsACL = true
+	if xerr := p.Chmod(); xerr != nil {
+		t.Fatalf("failed to change permissions: %v", xerr)
+	}
+	if inherited(p.FSPath) {
+		t.Errorf("expected inherited entries to be replaced when WindowsACL is true")
+	}
+}
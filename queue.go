@@ -0,0 +1,194 @@
+package types
+
+// dequeMinCapacity is the initial size of the ring buffer backing a [Deque] object.
+const dequeMinCapacity = 16
+
+// Deque is a generic double-ended queue backed by a growable ring buffer.
+//
+// Elements can be pushed and popped at either end in amortized constant time without the copying involved in shifting
+// a slice. If a maximum length is set, pushes fail once the deque is full rather than growing it further.
+//
+// The zero value is an empty, unbounded deque ready to use. Deque objects are not safe for concurrent use.
+type Deque[T any] struct {
+	buf    []T
+	head   int
+	length int
+	maxLen int
+}
+
+// NewDeque creates a new, empty Deque object which holds at most maxLen elements.
+//
+// A maxLen of 0 or less means the deque is unbounded.
+func NewDeque[T any](maxLen int) *Deque[T] {
+	return &Deque[T]{maxLen: max(maxLen, 0)}
+}
+
+// Clear removes all elements from the deque.
+func (d *Deque[T]) Clear() {
+	clear(d.buf)
+	d.head, d.length = 0, 0
+}
+
+// Len returns the number of elements in the deque.
+func (d *Deque[T]) Len() int {
+	return d.length
+}
+
+// MaxLen returns the maximum number of elements the deque can hold or 0 if it is unbounded.
+func (d *Deque[T]) MaxLen() int {
+	return d.maxLen
+}
+
+// PeekBack returns the element at the back of the deque without removing it.
+//
+// If the deque is empty, the zero value and false are returned.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	if d.length == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.index(d.length-1)], true
+}
+
+// PeekFront returns the element at the front of the deque without removing it.
+//
+// If the deque is empty, the zero value and false are returned.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	if d.length == 0 {
+		var zero T
+		return zero, false
+	}
+	return d.buf[d.head], true
+}
+
+// PopBack removes and returns the element at the back of the deque.
+//
+// If the deque is empty, the zero value and false are returned.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.length == 0 {
+		return zero, false
+	}
+	i := d.index(d.length - 1)
+	val := d.buf[i]
+	d.buf[i] = zero
+	d.length--
+	return val, true
+}
+
+// PopFront removes and returns the element at the front of the deque.
+//
+// If the deque is empty, the zero value and false are returned.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.length == 0 {
+		return zero, false
+	}
+	val := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.length--
+	return val, true
+}
+
+// PushBack adds the given element to the back of the deque.
+//
+// If the deque is already at its maximum length, the element is not added and false is returned.
+func (d *Deque[T]) PushBack(val T) bool {
+	if !d.grow() {
+		return false
+	}
+	d.buf[d.index(d.length)] = val
+	d.length++
+	return true
+}
+
+// PushFront adds the given element to the front of the deque.
+//
+// If the deque is already at its maximum length, the element is not added and false is returned.
+func (d *Deque[T]) PushFront(val T) bool {
+	if !d.grow() {
+		return false
+	}
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = val
+	d.length++
+	return true
+}
+
+// grow makes room for one more element, doubling the ring buffer if it is full.
+//
+// It returns false if the deque is already at its maximum length.
+func (d *Deque[T]) grow() bool {
+	if d.maxLen > 0 && d.length >= d.maxLen {
+		return false
+	}
+	if d.length < len(d.buf) {
+		return true
+	}
+
+	size := max(len(d.buf)*2, dequeMinCapacity)
+	if d.maxLen > 0 {
+		size = min(size, d.maxLen)
+	}
+	buf := make([]T, size)
+	n := copy(buf, d.buf[d.head:])
+	copy(buf[n:], d.buf[:d.head])
+	d.buf, d.head = buf, 0
+	return true
+}
+
+// index converts the given position relative to the front of the deque into an index in the ring buffer.
+func (d *Deque[T]) index(pos int) int {
+	return (d.head + pos) % len(d.buf)
+}
+
+// Queue is a generic first-in, first-out queue backed by a growable ring buffer.
+//
+// The zero value is an empty, unbounded queue ready to use. Queue objects are not safe for concurrent use.
+type Queue[T any] struct {
+	d Deque[T]
+}
+
+// NewQueue creates a new, empty Queue object which holds at most maxLen elements.
+//
+// A maxLen of 0 or less means the queue is unbounded.
+func NewQueue[T any](maxLen int) *Queue[T] {
+	return &Queue[T]{d: Deque[T]{maxLen: max(maxLen, 0)}}
+}
+
+// Clear removes all elements from the queue.
+func (q *Queue[T]) Clear() {
+	q.d.Clear()
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	return q.d.Len()
+}
+
+// MaxLen returns the maximum number of elements the queue can hold or 0 if it is unbounded.
+func (q *Queue[T]) MaxLen() int {
+	return q.d.MaxLen()
+}
+
+// Peek returns the element at the front of the queue without removing it.
+//
+// If the queue is empty, the zero value and false are returned.
+func (q *Queue[T]) Peek() (T, bool) {
+	return q.d.PeekFront()
+}
+
+// Pop removes and returns the element at the front of the queue.
+//
+// If the queue is empty, the zero value and false are returned.
+func (q *Queue[T]) Pop() (T, bool) {
+	return q.d.PopFront()
+}
+
+// Push adds the given element to the back of the queue.
+//
+// If the queue is already at its maximum length, the element is not added and false is returned.
+func (q *Queue[T]) Push(val T) bool {
+	return q.d.PushBack(val)
+}
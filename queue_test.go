@@ -0,0 +1,49 @@
+package types_test
+
+import (
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestQueue1(t *testing.T) {
+	var q types.Queue[int]
+	for i := 0; i < 100; i++ {
+		q.Push(i)
+		if i%3 == 0 {
+			q.Pop()
+		}
+	}
+	next, _ := q.Peek()
+	t.Logf("queue length: %d, next: %d", q.Len(), next)
+	for want := next; q.Len() > 0; want++ {
+		if got, _ := q.Pop(); got != want {
+			t.Fatalf("expected %d but got %d", want, got)
+		}
+	}
+
+	bounded := types.NewQueue[string](2)
+	if !bounded.Push("a") || !bounded.Push("b") || bounded.Push("c") {
+		t.Errorf("expected push beyond maximum length to fail")
+	}
+}
+
+func TestDeque1(t *testing.T) {
+	d := types.NewDeque[int](0)
+	for i := 1; i <= 20; i++ {
+		if i%2 == 0 {
+			d.PushFront(i)
+		} else {
+			d.PushBack(i)
+		}
+	}
+	front, _ := d.PopFront()
+	back, _ := d.PopBack()
+	if front != 20 || back != 19 || d.Len() != 18 {
+		t.Errorf("unexpected deque ends: front=%d back=%d len=%d", front, back, d.Len())
+	}
+	d.Clear()
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("expected cleared deque to be empty")
+	}
+}
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Range represents an inclusive range of ordered values such as ports, counts or scores.
+//
+// It is marshaled to JSON as an object with "min" and "max" fields and can be unmarshaled from either that object or
+// from a string in "min..max" format. In both cases, the minimum must not be greater than the maximum.
+type Range[T cmp.Ordered] struct {
+	// Min is the lowest value in the range.
+	Min T `json:"min" yaml:"min" mapstructure:"min"`
+
+	// Max is the highest value in the range.
+	Max T `json:"max" yaml:"max" mapstructure:"max"`
+}
+
+// NewRange creates a new Range object from the given minimum and maximum, validating that min is not greater than
+// max.
+func NewRange[T cmp.Ordered](min, max T) (Range[T], error) {
+	r := Range[T]{Min: min, Max: max}
+	if err := r.Validate(); err != nil {
+		return Range[T]{}, err
+	}
+	return r, nil
+}
+
+// ParseRange parses the given string in "min..max" format into a [Range] object, such as "1024..65535".
+func ParseRange[T cmp.Ordered](rng string) (Range[T], error) {
+	minStr, maxStr, found := strings.Cut(strings.TrimSpace(rng), "..")
+	if !found {
+		return Range[T]{}, fmt.Errorf("failed to parse range '%s': expected 'min..max'", rng)
+	}
+	min, err := parseRangeValue[T](strings.TrimSpace(minStr))
+	if err != nil {
+		return Range[T]{}, fmt.Errorf("failed to parse range '%s': invalid minimum: %w", rng, err)
+	}
+	max, err := parseRangeValue[T](strings.TrimSpace(maxStr))
+	if err != nil {
+		return Range[T]{}, fmt.Errorf("failed to parse range '%s': invalid maximum: %w", rng, err)
+	}
+	r, err := NewRange(min, max)
+	if err != nil {
+		return Range[T]{}, fmt.Errorf("failed to parse range '%s': %w", rng, err)
+	}
+	return r, nil
+}
+
+// Clamp returns the given value limited to the range.
+func (r Range[T]) Clamp(v T) T {
+	return min(max(v, r.Min), r.Max)
+}
+
+// Contains returns whether or not the given value is within the range, inclusively.
+func (r Range[T]) Contains(v T) bool {
+	return v >= r.Min && v <= r.Max
+}
+
+// String returns the [Range] object as a string in "min..max" format.
+func (r Range[T]) String() string {
+	return fmt.Sprintf("%v..%v", r.Min, r.Max)
+}
+
+// UnmarshalJSON parses the JSON data into a [Range] object.
+//
+// The data may be an object with "min" and "max" fields or a string in "min..max" format.
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err == nil {
+		return r.UnmarshalText([]byte(sval))
+	}
+
+	// use a separate type to avoid recursing into this function
+	type rangeObject Range[T]
+	var obj rangeObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	if err := Range[T](obj).Validate(); err != nil {
+		return err
+	}
+	*r = Range[T](obj)
+	return nil
+}
+
+// UnmarshalText parses the text in "min..max" format into a [Range] object.
+func (r *Range[T]) UnmarshalText(data []byte) error {
+	rng, err := ParseRange[T](string(data))
+	if err != nil {
+		return err
+	}
+	*r = rng
+	return nil
+}
+
+// Validate returns an error if the minimum is greater than the maximum.
+func (r Range[T]) Validate() error {
+	if r.Min > r.Max {
+		return fmt.Errorf("range minimum %v must not be greater than maximum %v", r.Min, r.Max)
+	}
+	return nil
+}
+
+// parseRangeValue parses a single value of a range, which may be a number or a string.
+func parseRangeValue[T cmp.Ordered](str string) (T, error) {
+	var v T
+	if err := json.Unmarshal([]byte(str), &v); err == nil {
+		return v, nil
+	}
+	quoted, _ := json.Marshal(str)
+	if err := json.Unmarshal(quoted, &v); err != nil {
+		return v, fmt.Errorf("'%s' is not a valid %T", str, v)
+	}
+	return v, nil
+}
@@ -0,0 +1,38 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestRange1(t *testing.T) {
+	var config struct {
+		Ports   types.Range[int]     `json:"ports"`
+		Workers types.Range[int]     `json:"workers"`
+		Score   types.Range[float64] `json:"score"`
+		Shards  types.Range[string]  `json:"shards"`
+	}
+	data := `{"ports": "1024..65535", "workers": {"min": 2, "max": 16}, "score": "-0.5..1.5", "shards": "a..m"}`
+	if err := json.Unmarshal([]byte(data), &config); err != nil {
+		t.Fatalf("failed to unmarshal ranges: %v", err)
+	}
+	if !config.Ports.Contains(8080) || config.Ports.Contains(80) || config.Workers.Clamp(64) != 16 ||
+		config.Score.Clamp(-1) != -0.5 || !config.Shards.Contains("go") {
+		t.Errorf("unexpected range results: %+v", config)
+	}
+	out, _ := json.Marshal(config.Workers)
+	if string(out) != `{"min":2,"max":16}` {
+		t.Errorf("unexpected JSON: %s", out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"workers": {"min": 16, "max": 2}}`), &config); err == nil {
+		t.Errorf("expected inverted range to be rejected")
+	}
+	for _, str := range []string{"10", "10..abc", "65535..1024"} {
+		if _, err := types.ParseRange[int](str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// RawJSON is a raw, encoded JSON value which is carried through marshaling and unmarshaling untouched.
+//
+// It is similar to [json.RawMessage] but copies the data it is given so that it never aliases a caller's buffer, and
+// it can also be marshaled to and unmarshaled from YAML by libraries which support the MarshalYAML and
+// UnmarshalYAML(func(any) error) methods, such as gopkg.in/yaml.v3. This makes it suitable for holding
+// plugin-specific configuration which is decoded later by the plugin itself.
+type RawJSON []byte
+
+// Clone returns a deep copy of the raw JSON.
+func (r RawJSON) Clone() RawJSON {
+	if r == nil {
+		return nil
+	}
+	return bytes.Clone(r)
+}
+
+// Decode unmarshals the raw JSON into v.
+func (r RawJSON) Decode(v any) error {
+	return json.Unmarshal(r.bytes(), v)
+}
+
+// IsNull returns whether or not the raw JSON is empty or a JSON null.
+func (r RawJSON) IsNull() bool {
+	return bytes.Equal(r.bytes(), []byte("null"))
+}
+
+// MarshalJSON returns the raw JSON, or null if it is empty.
+func (r RawJSON) MarshalJSON() ([]byte, error) {
+	return r.bytes(), nil
+}
+
+// MarshalYAML returns the raw JSON decoded into a generic value so that a YAML library can encode it.
+func (r RawJSON) MarshalYAML() (any, error) {
+	var v any
+	if err := json.Unmarshal(r.bytes(), &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// String returns the raw JSON as a string.
+func (r RawJSON) String() string {
+	return string(r.bytes())
+}
+
+// UnmarshalJSON stores a copy of the JSON data.
+func (r *RawJSON) UnmarshalJSON(data []byte) error {
+	*r = bytes.Clone(data)
+	return nil
+}
+
+// UnmarshalYAML decodes a YAML value and stores it as raw JSON.
+//
+// Mappings with non-string keys are converted to JSON objects by formatting each key as a string.
+func (r *RawJSON) UnmarshalYAML(unmarshal func(any) error) error {
+	var v any
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+	data, err := json.Marshal(yamlToJSONValue(v))
+	if err != nil {
+		return err
+	}
+	*r = data
+	return nil
+}
+
+// bytes returns the raw JSON or a JSON null if it is empty.
+func (r RawJSON) bytes() []byte {
+	if len(r) == 0 {
+		return []byte("null")
+	}
+	return r
+}
+
+// yamlToJSONValue converts the maps with non-string keys produced by some YAML libraries into maps which can be
+// marshaled to JSON.
+func yamlToJSONValue(v any) any {
+	switch val := v.(type) {
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[fmt.Sprintf("%v", k)] = yamlToJSONValue(item)
+		}
+		return m
+	case map[string]any:
+		for k, item := range val {
+			val[k] = yamlToJSONValue(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = yamlToJSONValue(item)
+		}
+		return val
+	}
+	return v
+}
@@ -0,0 +1,48 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestRawJSON1(t *testing.T) {
+	var config struct {
+		Plugin  string        `json:"plugin"`
+		Options types.RawJSON `json:"options"`
+		Extra   types.RawJSON `json:"extra"`
+	}
+	data := []byte(`{"plugin": "s3", "options": {"bucket": "logs", "regions": ["us-east-1"]}}`)
+	if err := json.Unmarshal(data, &config); err != nil {
+		t.Fatalf("failed to unmarshal raw JSON: %v", err)
+	}
+	clone := config.Options.Clone()
+	copy(data, "XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX")
+	if config.Options.String() != `{"bucket": "logs", "regions": ["us-east-1"]}` || clone.String() != config.Options.String() {
+		t.Errorf("expected raw JSON to be preserved exactly but got: %s", config.Options)
+	}
+	if !config.Extra.IsNull() {
+		t.Errorf("expected missing raw JSON to be null")
+	}
+
+	var options struct {
+		Bucket string `json:"bucket"`
+	}
+	if err := config.Options.Decode(&options); err != nil || options.Bucket != "logs" {
+		t.Errorf("failed to decode raw JSON: %v", err)
+	}
+
+	// simulate a YAML library which decodes mappings with non-string keys
+	var fromYAML types.RawJSON
+	err := fromYAML.UnmarshalYAML(func(v any) error {
+		*(v.(*any)) = map[any]any{"bucket": "logs", 1: []any{map[any]any{"nested": true}}}
+		return nil
+	})
+	if err != nil || fromYAML.String() != `{"1":[{"nested":true}],"bucket":"logs"}` {
+		t.Errorf("unexpected raw JSON from YAML: %s (%v)", fromYAML, err)
+	}
+	if v, err := fromYAML.MarshalYAML(); err != nil || v.(map[string]any)["bucket"] != "logs" {
+		t.Errorf("unexpected YAML value: %v (%v)", v, err)
+	}
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Clock provides the current time so that relative times can be resolved against something other than the system
+// clock, such as a fixed time in unit tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// SystemClock is a [Clock] which returns the current system time.
+type SystemClock struct{}
+
+// Now returns the current system time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// RelativeTime is a point in time which is expressed relative to the time at which it is resolved.
+//
+// It is parsed from strings such as "now", "now-15m", "now+2d" or "2h ago", where the duration may be anything
+// accepted by [ParseDuration].
+type RelativeTime struct {
+	// Offset is the amount of time to add to the current time when the relative time is resolved. Negative values
+	// refer to times in the past.
+	Offset Duration
+}
+
+// ParseRelativeTime parses the given string into a [RelativeTime] object.
+//
+// If an empty string is supplied, a [RelativeTime] object referring to the current time is returned.
+func ParseRelativeTime(rel string) (RelativeTime, error) {
+	str := strings.TrimSpace(rel)
+	if str == "" || str == "now" {
+		return RelativeTime{}, nil
+	}
+
+	// "<duration> ago"
+	if dur, found := strings.CutSuffix(str, " ago"); found {
+		offset, err := ParseDuration(strings.TrimSpace(dur))
+		if err != nil {
+			return RelativeTime{}, fmt.Errorf("failed to parse relative time '%s': %w", rel, err)
+		}
+		return RelativeTime{Offset: -offset}, nil
+	}
+
+	// "now+<duration>" or "now-<duration>"
+	dur, found := strings.CutPrefix(str, "now")
+	dur = strings.TrimSpace(dur)
+	if !found || (!strings.HasPrefix(dur, "+") && !strings.HasPrefix(dur, "-")) {
+		return RelativeTime{}, fmt.Errorf("failed to parse relative time '%s': expected 'now', 'now+<duration>', "+
+			"'now-<duration>' or '<duration> ago'", rel)
+	}
+	offset, err := ParseDuration(strings.TrimSpace(dur[1:]))
+	if err != nil {
+		return RelativeTime{}, fmt.Errorf("failed to parse relative time '%s': %w", rel, err)
+	}
+	if dur[0] == '-' {
+		offset = -offset
+	}
+	return RelativeTime{Offset: offset}, nil
+}
+
+// MarshalJSON marshals the [RelativeTime] object to JSON.
+func (r RelativeTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// MarshalText marshals the [RelativeTime] object to plain text.
+func (r RelativeTime) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// Resolve returns the point in time referred to by the [RelativeTime] object using the current time from the given
+// clock.
+//
+// If clock is nil, [SystemClock] is used.
+func (r RelativeTime) Resolve(clock Clock) time.Time {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return clock.Now().Add(time.Duration(r.Offset))
+}
+
+// String returns the [RelativeTime] object as a string, such as "now-15m0s" or "now+2d".
+func (r RelativeTime) String() string {
+	switch {
+	case r.Offset < 0:
+		return "now-" + (-r.Offset).marshalString()
+	case r.Offset > 0:
+		return "now+" + r.Offset.marshalString()
+	}
+	return "now"
+}
+
+// UnmarshalJSON parses the JSON data into a [RelativeTime] object.
+func (r *RelativeTime) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	rel, err := ParseRelativeTime(sval)
+	if err != nil {
+		return err
+	}
+	*r = rel
+	return nil
+}
+
+// UnmarshalText parses the text into a [RelativeTime] object.
+func (r *RelativeTime) UnmarshalText(data []byte) error {
+	rel, err := ParseRelativeTime(string(data))
+	if err != nil {
+		return err
+	}
+	*r = rel
+	return nil
+}
@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+// TODO: implement additional testing and benchmarks
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestRelativeTime1(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	times := map[string]time.Time{
+		"now":        now,
+		"now-15m":    now.Add(-15 * time.Minute),
+		"now + 2d":   now.Add(48 * time.Hour),
+		"2h ago":     now.Add(-2 * time.Hour),
+		"1d12h ago":  now.Add(-36 * time.Hour),
+		"now-P1DT2H": now.Add(-26 * time.Hour),
+	}
+	for str, expected := range times {
+		rel, err := types.ParseRelativeTime(str)
+		if err != nil {
+			t.Errorf("failed to parse relative time: %v", err)
+			continue
+		}
+		if resolved := rel.Resolve(fixedClock(now)); !resolved.Equal(expected) {
+			t.Errorf("expected '%s' to resolve to %s but got %s", str, expected, resolved)
+		}
+		t.Logf("relative time: %s", rel)
+	}
+	for _, str := range []string{"yesterday", "now15m", "15m"} {
+		if _, err := types.ParseRelativeTime(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
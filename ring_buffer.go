@@ -0,0 +1,55 @@
+package types
+
+// RingBuffer is a generic fixed-capacity buffer which overwrites its oldest element once it is full.
+//
+// It is useful for keeping only the most recent N items in memory, such as log lines or metrics samples.
+//
+// RingBuffer objects are not safe for concurrent use.
+type RingBuffer[T any] struct {
+	buf    []T
+	head   int
+	length int
+}
+
+// NewRingBuffer creates a new, empty RingBuffer object which holds at most capacity elements.
+//
+// A capacity of less than 1 is treated as 1.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	return &RingBuffer[T]{buf: make([]T, max(capacity, 1))}
+}
+
+// Cap returns the maximum number of elements the buffer can hold.
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.buf)
+}
+
+// Clear removes all elements from the buffer.
+func (r *RingBuffer[T]) Clear() {
+	clear(r.buf)
+	r.head, r.length = 0, 0
+}
+
+// Len returns the number of elements in the buffer.
+func (r *RingBuffer[T]) Len() int {
+	return r.length
+}
+
+// Push adds one or more elements to the buffer, overwriting the oldest elements if it is full.
+func (r *RingBuffer[T]) Push(vals ...T) {
+	for _, v := range vals {
+		r.buf[(r.head+r.length)%len(r.buf)] = v
+		if r.length < len(r.buf) {
+			r.length++
+		} else {
+			r.head = (r.head + 1) % len(r.buf)
+		}
+	}
+}
+
+// Snapshot returns a copy of the elements in the buffer, ordered from oldest to newest.
+func (r *RingBuffer[T]) Snapshot() []T {
+	result := make([]T, r.length)
+	n := copy(result, r.buf[r.head:min(r.head+r.length, len(r.buf))])
+	copy(result[n:], r.buf[:r.length-n])
+	return result
+}
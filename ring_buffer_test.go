@@ -0,0 +1,24 @@
+package types_test
+
+import (
+	"slices"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestRingBuffer1(t *testing.T) {
+	lines := types.NewRingBuffer[string](3)
+	lines.Push("one", "two")
+	if snap := lines.Snapshot(); !slices.Equal(snap, []string{"one", "two"}) {
+		t.Errorf("unexpected snapshot before wrapping: %v", snap)
+	}
+	lines.Push("three", "four", "five")
+	if snap := lines.Snapshot(); !slices.Equal(snap, []string{"three", "four", "five"}) || lines.Len() != lines.Cap() {
+		t.Errorf("unexpected snapshot after wrapping: %v", snap)
+	}
+	lines.Clear()
+	if lines.Len() != 0 || len(lines.Snapshot()) != 0 {
+		t.Errorf("expected cleared buffer to be empty")
+	}
+}
@@ -0,0 +1,381 @@
+package types
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.innotegrity.dev/xerrors"
+)
+
+// rotatingWriterTimeFormat is the format of the timestamp added to the name of rotated files.
+const rotatingWriterTimeFormat = "20060102T150405.000000000"
+
+// RotatingWriterOptions holds the settings used by a [RotatingWriter].
+type RotatingWriterOptions struct {
+	// Compress indicates if rotated files should be compressed using gzip.
+	Compress bool `json:"compress" yaml:"compress" mapstructure:"compress"`
+
+	// MaxAge is the maximum amount of time a file is written to before it is rotated. If 0, files are not rotated
+	// based on age.
+	MaxAge Duration `json:"max_age" yaml:"max_age" mapstructure:"max_age"`
+
+	// MaxBackups is the maximum number of rotated files to keep. If 0, all rotated files are kept.
+	MaxBackups int `json:"max_backups" yaml:"max_backups" mapstructure:"max_backups"`
+
+	// MaxSize is the maximum size a file may reach before it is rotated. If 0, files are not rotated based on size.
+	MaxSize Size `json:"max_size" yaml:"max_size" mapstructure:"max_size"`
+}
+
+// rotatedFile describes a file which was rotated by a [RotatingWriter].
+type rotatedFile struct {
+	name string
+	time time.Time
+}
+
+// RotatingWriter is an [io.WriteCloser] which writes to a file and rotates it once it grows too large or too old.
+//
+// Rotated files are renamed by adding a timestamp before the file's extension (eg: "app.log" becomes
+// "app-20250102T150405.000000000.log") and are optionally compressed. Each new file is created using
+// [Path.OpenFS], so the [Path.AutoCreateParent], [Path.AutoChmod] and [Path.AutoChown] settings are honored for
+// every file.
+//
+// Rotated files are compressed and old backups are removed on a background goroutine, so writes are not blocked while
+// that happens. Any errors which occur in the background are returned by [RotatingWriter.Close].
+//
+// It is safe to use a [RotatingWriter] from multiple goroutines.
+type RotatingWriter struct {
+	bgErrs  []error
+	bgMu    sync.Mutex
+	bgWG    sync.WaitGroup
+	closed  bool
+	errsMu  sync.Mutex
+	file    File
+	mu      sync.Mutex
+	opened  time.Time
+	options RotatingWriterOptions
+	path    Path
+	size    int64
+}
+
+// NewRotatingWriter creates a new [RotatingWriter] which writes to the file.
+//
+// The file is opened in append mode immediately, so any existing contents are kept. The path must not have
+// [Path.Compression] set; use [RotatingWriterOptions.Compress] to compress files once they are rotated instead.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the file/parent folder
+//   - [PathChownError]: there was an error while changing ownership of the file/parent folder
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path or [Path.Compression] is set
+//   - [PathOpenFileError]: there was an error while opening the file
+func (p Path) NewRotatingWriter(opts RotatingWriterOptions) (*RotatingWriter, xerrors.Error) {
+	if xerr := p.autoExpand(); xerr != nil {
+		return nil, xerr
+	}
+
+	// the size limit is measured in uncompressed bytes, which would not match the size of a compressed file on disk
+	if p.Compression != CompressionNone {
+		err := errors.New("compression is not supported; use RotatingWriterOptions.Compress to compress rotated files")
+		return nil, xerrors.Wrapf(PathError, err, "failed to open file '%s': %s", p.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":        p.FSPath,
+				"compression": p.Compression.String(),
+			})
+	}
+	w := &RotatingWriter{
+		options: opts,
+		path:    p,
+	}
+	if xerr := w.open(); xerr != nil {
+		return nil, xerr
+	}
+	return w, nil
+}
+
+// Close closes the current file and waits for any rotated files to finish being compressed and pruned.
+//
+// Any errors which occurred while compressing or pruning rotated files in the background are returned along with
+// any error from closing the file. Once closed, the writer cannot be used again.
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	var err error
+	if !w.closed && w.file != nil {
+		err = w.file.Close()
+	}
+	w.closed = true
+	w.file = nil
+	w.mu.Unlock()
+
+	w.bgWG.Wait()
+	w.errsMu.Lock()
+	defer w.errsMu.Unlock()
+	err = errors.Join(append([]error{err}, w.bgErrs...)...)
+	w.bgErrs = nil
+	return err
+}
+
+// Rotate closes the current file, renames it and opens a new file in its place.
+//
+// The rotated file is compressed and old backups are removed in the background.
+//
+// This function may return an error with any of the following codes:
+//   - [PathChmodError]: there was an error while changing the permissions on the new file
+//   - [PathChownError]: there was an error while changing ownership of the new file
+//   - [PathCreateError]: there was an error while creating the parent folder
+//   - [PathError]: there was a general error while working with the path
+//   - [PathMoveError]: there was an error while renaming the current file
+//   - [PathOpenFileError]: there was an error while opening the new file
+//   - [PathWriteError]: there was an error while closing the rotated file or the writer is closed
+func (w *RotatingWriter) Rotate() xerrors.Error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return w.closedError()
+	}
+	return w.rotate()
+}
+
+// Write writes the data to the current file, rotating it first if the write would exceed the maximum size or the
+// file has exceeded its maximum age.
+//
+// An error wrapping [os.ErrClosed] is returned if the writer has been closed.
+func (w *RotatingWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, w.closedError()
+	}
+	if w.file == nil {
+		if xerr := w.open(); xerr != nil {
+			return 0, xerr
+		}
+	}
+
+	// rotate the file if needed
+	tooLarge := w.options.MaxSize > 0 && w.size > 0 && w.size+int64(len(data)) > int64(w.options.MaxSize)
+	tooOld := w.options.MaxAge > 0 && time.Since(w.opened) >= time.Duration(w.options.MaxAge)
+	if tooLarge || tooOld {
+		if xerr := w.rotate(); xerr != nil {
+			return 0, xerr
+		}
+	}
+
+	n, err := w.file.Write(data)
+	w.size += int64(n)
+	if err != nil {
+		return n, xerrors.Wrapf(PathWriteError, err, "failed to write to file '%s': %s", w.path.FSPath,
+			err.Error()).WithAttr("file", w.path.FSPath)
+	}
+	return n, nil
+}
+
+// backupName returns the name of the rotated file for the given time.
+func (w *RotatingWriter) backupName(t time.Time) string {
+	dir, base := filepath.Split(w.path.FSPath)
+	ext := filepath.Ext(base)
+	return filepath.Join(dir, fmt.Sprintf("%s-%s%s", strings.TrimSuffix(base, ext), t.Format(rotatingWriterTimeFormat),
+		ext))
+}
+
+// backupTime returns the time at which the rotated file with the given base name was created.
+//
+// The name must be exactly "<base>-<timestamp><ext>" or "<base>-<timestamp><ext>.gz", where the timestamp is in
+// rotatingWriterTimeFormat, or false is returned.
+func (w *RotatingWriter) backupTime(name string) (time.Time, bool) {
+	base := filepath.Base(w.path.FSPath)
+	ext := filepath.Ext(base)
+	stamp, found := strings.CutPrefix(strings.TrimSuffix(name, ".gz"), strings.TrimSuffix(base, ext)+"-")
+	if !found {
+		return time.Time{}, false
+	}
+	if stamp, found = strings.CutSuffix(stamp, ext); !found || len(stamp) != len(rotatingWriterTimeFormat) {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(rotatingWriterTimeFormat, stamp, time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// backups returns the rotated files in the same folder as the current file, newest first.
+//
+// Only files whose names parse exactly as a rotated file name (see [RotatingWriter.backupTime]) are returned.
+func (w *RotatingWriter) backups() ([]rotatedFile, xerrors.Error) {
+	dir := filepath.Dir(w.path.FSPath)
+	entries, err := w.path.fs().ReadDir(dir)
+	if err != nil {
+		return nil, xerrors.Wrapf(PathError, err, "failed to read folder '%s': %s", dir, err.Error()).
+			WithAttr("path", dir)
+	}
+	var backups []rotatedFile
+	for _, e := range entries {
+		if t, ok := w.backupTime(e.Name()); ok && !e.IsDir() {
+			backups = append(backups, rotatedFile{name: filepath.Join(dir, e.Name()), time: t})
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].time.After(backups[j].time)
+	})
+	return backups, nil
+}
+
+// cleanup compresses the given rotated file, if needed, and removes the oldest backups on a background goroutine.
+//
+// Cleanups are performed one at a time in the order in which they were started. Any errors are saved so that they can
+// be returned by [RotatingWriter.Close].
+func (w *RotatingWriter) cleanup(backup string, compress bool) {
+	w.bgWG.Add(1)
+	go func() {
+		defer w.bgWG.Done()
+		w.bgMu.Lock()
+		defer w.bgMu.Unlock()
+
+		var xerr xerrors.Error
+		if compress {
+			xerr = w.compress(backup)
+		}
+		if xerr == nil {
+			xerr = w.prune()
+		}
+		if xerr != nil {
+			w.errsMu.Lock()
+			w.bgErrs = append(w.bgErrs, xerr)
+			w.errsMu.Unlock()
+		}
+	}()
+}
+
+// closedError returns the error used when the writer is used after it has been closed.
+func (w *RotatingWriter) closedError() xerrors.Error {
+	return xerrors.Wrapf(PathWriteError, os.ErrClosed, "failed to write to file '%s': %s", w.path.FSPath,
+		os.ErrClosed.Error()).WithAttr("file", w.path.FSPath)
+}
+
+// compress compresses the given rotated file using gzip and removes the uncompressed file.
+func (w *RotatingWriter) compress(name string) xerrors.Error {
+	src := w.path.withFSPath(name)
+	dest := w.path.withFSPath(name + ".gz")
+	dest.Compression = CompressionNone
+	fsys := w.path.fs()
+
+	in, err := fsys.Open(name)
+	if err != nil {
+		return xerrors.Wrapf(PathOpenFileError, err, "failed to open file '%s': %s", name, err.Error()).
+			WithAttr("file", name)
+	}
+	defer in.Close()
+	out, xerr := dest.OpenFS(os.O_CREATE | os.O_WRONLY | os.O_TRUNC)
+	if xerr != nil {
+		return xerr
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	_, err = io.CopyBuffer(gz, in, make([]byte, copyBufferSize))
+	if err == nil {
+		err = gz.Close()
+	}
+	if err == nil {
+		err = out.Close()
+	}
+	if err != nil {
+		fsys.Remove(dest.FSPath)
+		return xerrors.Wrapf(PathWriteError, err, "failed to compress file '%s': %s", name, err.Error()).
+			WithAttr("file", name)
+	}
+	in.Close()
+	return src.Remove()
+}
+
+// created returns the time at which the existing current file, last modified at the given time, was created.
+//
+// The file is created when the previous one is rotated, so this is the time at which the newest backup was made. If
+// there are no backups, the modification time is used instead.
+func (w *RotatingWriter) created(modTime time.Time) time.Time {
+	if backups, xerr := w.backups(); xerr == nil && len(backups) > 0 && backups[0].time.Before(modTime) {
+		return backups[0].time
+	}
+	return modTime
+}
+
+// open opens the current file for appending.
+func (w *RotatingWriter) open() xerrors.Error {
+	file, xerr := w.path.OpenFS(os.O_CREATE | os.O_WRONLY | os.O_APPEND)
+	if xerr != nil {
+		return xerr
+	}
+	w.file = file
+	w.opened = time.Now()
+	w.size = 0
+
+	// when appending to an existing file, measure its age from when it was created rather than from now so that the
+	// maximum age is still honored if the process is restarted frequently
+	if info, err := file.Stat(); err == nil {
+		w.size = info.Size()
+		if w.size > 0 {
+			w.opened = w.created(info.ModTime())
+		}
+	}
+	return nil
+}
+
+// prune removes the oldest rotated files so that no more than the maximum number of backups are kept.
+//
+// Only files whose names parse exactly as a rotated file name (see [RotatingWriter.backupTime]) are considered, so
+// other files in the folder which happen to share the same prefix are never removed.
+func (w *RotatingWriter) prune() xerrors.Error {
+	if w.options.MaxBackups <= 0 {
+		return nil
+	}
+	backups, xerr := w.backups()
+	if xerr != nil {
+		return xerr
+	}
+	for i := w.options.MaxBackups; i < len(backups); i++ {
+		if xerr := w.path.withFSPath(backups[i].name).Remove(); xerr != nil {
+			return xerr
+		}
+	}
+	return nil
+}
+
+// rotate closes the current file, renames it and opens a new one.
+//
+// The caller must hold the lock.
+func (w *RotatingWriter) rotate() xerrors.Error {
+	if w.file != nil {
+		err := w.file.Close()
+		w.file = nil
+		if err != nil {
+			return xerrors.Wrapf(PathWriteError, err, "failed to close file '%s': %s", w.path.FSPath, err.Error()).
+				WithAttr("file", w.path.FSPath)
+		}
+	}
+
+	// move the current file out of the way
+	backup := w.backupName(time.Now())
+	err := w.path.fs().Rename(w.path.FSPath, backup)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return xerrors.Wrapf(PathMoveError, err, "failed to rotate file '%s': %s", w.path.FSPath, err.Error()).
+			WithAttrs(map[string]any{
+				"file":   w.path.FSPath,
+				"backup": backup,
+			})
+	}
+	if xerr := w.open(); xerr != nil {
+		return xerr
+	}
+
+	// clean up the backups without blocking writers
+	w.cleanup(backup, w.options.Compress && err == nil)
+	return nil
+}
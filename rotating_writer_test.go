@@ -0,0 +1,161 @@
+package types_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestRotatingWriter1(t *testing.T) {
+	dir := t.TempDir()
+	siblings := []string{"app-access.log", "app-20250102T150405.log", "app-x20250102T150405.000000000.log", "app[1].log"}
+	for _, name := range siblings {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("keep"), 0644); err != nil {
+			t.Fatalf("failed to create file: %v", err)
+		}
+	}
+
+	for _, name := range []string{"app.log", "app[1].log"} {
+		w, xerr := types.NewPath(filepath.Join(dir, name)).NewRotatingWriter(types.RotatingWriterOptions{MaxBackups: 2})
+		if xerr != nil {
+			t.Fatalf("failed to create writer: %v", xerr)
+		}
+		for i := 0; i < 4; i++ {
+			if _, err := w.Write([]byte("line\n")); err != nil {
+				t.Fatalf("failed to write: %v", err)
+			}
+			if xerr := w.Rotate(); xerr != nil {
+				t.Fatalf("failed to rotate: %v", xerr)
+			}
+		}
+		w.Close()
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read folder: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	t.Logf("files: %v", names)
+	for _, name := range siblings {
+		if !slices.Contains(names, name) {
+			t.Errorf("expected unrelated file '%s' to be kept", name)
+		}
+	}
+	// 4 siblings + app.log + 2 backups of each writer
+	if len(names) != 4+1+2+2 {
+		t.Errorf("expected 9 files but found %d", len(names))
+	}
+}
+
+func TestRotatingWriter2(t *testing.T) {
+	p := types.NewPath(filepath.Join(t.TempDir(), "app.log"))
+	p.Compression = types.CompressionGzip
+	if _, xerr := p.NewRotatingWriter(types.RotatingWriterOptions{}); xerr == nil {
+		t.Errorf("expected a compressed path to be rejected")
+	}
+}
+
+func TestRotatingWriter3(t *testing.T) {
+	dir := t.TempDir()
+	w, xerr := types.NewPath(filepath.Join(dir, "app.log")).NewRotatingWriter(types.RotatingWriterOptions{
+		Compress: true,
+	})
+	if xerr != nil {
+		t.Fatalf("failed to create writer: %v", xerr)
+	}
+	if _, err := w.Write([]byte("line\n")); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	if xerr := w.Rotate(); xerr != nil {
+		t.Fatalf("failed to rotate: %v", xerr)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("failed to close writer: %v", err)
+	}
+
+	// the rotated file is compressed in the background before Close returns
+	matches, _ := filepath.Glob(filepath.Join(dir, "app-*.log*"))
+	if len(matches) != 1 || filepath.Ext(matches[0]) != ".gz" {
+		t.Errorf("expected a single compressed backup but found: %v", matches)
+	}
+
+	// the writer cannot be used once closed
+	if _, err := w.Write([]byte("line\n")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected writing to a closed writer to fail with os.ErrClosed but got: %v", err)
+	}
+	if xerr := w.Rotate(); !errors.Is(xerr, os.ErrClosed) {
+		t.Errorf("expected rotating a closed writer to fail with os.ErrClosed but got: %v", xerr)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "app.log")); len(data) != 0 {
+		t.Errorf("expected the current file not to be reopened but it contains %q", data)
+	}
+}
+
+func TestRotatingWriter4(t *testing.T) {
+	opts := types.RotatingWriterOptions{MaxAge: types.Duration(time.Hour)}
+	old := time.Now().Add(-2 * time.Hour)
+	backups := func(dir string) int {
+		matches, _ := filepath.Glob(filepath.Join(dir, "app-*.log"))
+		return len(matches)
+	}
+
+	// an existing file which has not been written to for longer than the maximum age is rotated on the first write
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(name, []byte("old\n"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	if err := os.Chtimes(name, old, old); err != nil {
+		t.Fatalf("failed to change file times: %v", err)
+	}
+	w, xerr := types.NewPath(name).NewRotatingWriter(opts)
+	if xerr != nil {
+		t.Fatalf("failed to create writer: %v", xerr)
+	}
+	w.Write([]byte("new\n"))
+	w.Close()
+	if n := backups(dir); n != 1 {
+		t.Errorf("expected a stale file to be rotated but found %d backups", n)
+	}
+
+	// a recently written file is rotated based on when it was created, which is when the newest backup was made
+	dir = t.TempDir()
+	name = filepath.Join(dir, "app.log")
+	if err := os.WriteFile(name, []byte("recent\n"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	backup := filepath.Join(dir, "app-"+old.Format("20060102T150405.000000000")+".log")
+	if err := os.WriteFile(backup, []byte("older\n"), 0644); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+	w, xerr = types.NewPath(name).NewRotatingWriter(opts)
+	if xerr != nil {
+		t.Fatalf("failed to create writer: %v", xerr)
+	}
+	w.Write([]byte("new\n"))
+	w.Close()
+	if n := backups(dir); n != 2 {
+		t.Errorf("expected a file created more than an hour ago to be rotated but found %d backups", n)
+	}
+
+	// a new file is not rotated
+	dir = t.TempDir()
+	w, xerr = types.NewPath(filepath.Join(dir, "app.log")).NewRotatingWriter(opts)
+	if xerr != nil {
+		t.Fatalf("failed to create writer: %v", xerr)
+	}
+	w.Write([]byte("new\n"))
+	w.Close()
+	if n := backups(dir); n != 0 {
+		t.Errorf("expected a new file not to be rotated but found %d backups", n)
+	}
+}
@@ -1,6 +1,12 @@
 package types
 
-import "fmt"
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
 
 // Set is a generic set of unique elements in any order.
 //
@@ -18,6 +24,40 @@ func NewSet[E comparable](vals ...E) Set[E] {
 	return s
 }
 
+// SetFromKeys creates a new Set object containing the keys of the given map.
+func SetFromKeys[K comparable, V any](m map[K]V) Set[K] {
+	s := make(Set[K], len(m))
+	for k := range m {
+		s[k] = struct{}{}
+	}
+	return s
+}
+
+// SetFromSliceFunc creates a new Set object containing the result of calling fn on each element of the given slice.
+func SetFromSliceFunc[T any, E comparable](vals []T, fn func(T) E) Set[E] {
+	s := make(Set[E], len(vals))
+	for _, v := range vals {
+		s[fn(v)] = struct{}{}
+	}
+	return s
+}
+
+// SetFromValues creates a new Set object containing the unique values of the given map.
+func SetFromValues[K comparable, V comparable](m map[K]V) Set[V] {
+	s := make(Set[V], len(m))
+	for _, v := range m {
+		s[v] = struct{}{}
+	}
+	return s
+}
+
+// SetSorted returns an iterator over the elements of the given set in ascending order.
+//
+// This is a function rather than a method on Set because methods cannot further constrain the element type.
+func SetSorted[E cmp.Ordered](s Set[E]) iter.Seq[E] {
+	return slices.Values(slices.Sorted(maps.Keys(s)))
+}
+
 // Add one or more values to the set.
 func (s Set[E]) Add(vals ...E) {
 	for _, v := range vals {
@@ -25,21 +65,90 @@ func (s Set[E]) Add(vals ...E) {
 	}
 }
 
+// All returns an iterator over the elements in the set in no particular order.
+func (s Set[E]) All() iter.Seq[E] {
+	return maps.Keys(s)
+}
+
+// Clear removes all elements from the set.
+func (s Set[E]) Clear() {
+	clear(s)
+}
+
+// Clone returns an independent copy of the set.
+func (s Set[E]) Clone() Set[E] {
+	result := make(Set[E], len(s))
+	for v := range s {
+		result[v] = struct{}{}
+	}
+	return result
+}
+
 // Contains returns whether or not the set contains the given element.
 func (s Set[E]) Contains(val E) bool {
 	_, exists := s[val]
 	return exists
 }
 
+// Discard removes the given element from the set and returns whether or not it was present.
+func (s Set[E]) Discard(val E) bool {
+	_, exists := s[val]
+	delete(s, val)
+	return exists
+}
+
+// Equal returns whether or not the set contains exactly the same elements as the given set.
+func (s Set[E]) Equal(s2 Set[E]) bool {
+	return len(s) == len(s2) && s.IsSubset(s2)
+}
+
 // Intersection returns the overlapping elements in each set.
 func (s Set[E]) Intersection(s2 Set[E]) Set[E] {
-	result := NewSet[E]()
+	// iterate over the smaller set since the result can be no larger than it
+	small, large := s, s2
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	result := make(Set[E], len(small))
+	for v := range small {
+		if _, exists := large[v]; exists {
+			result[v] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsDisjoint returns whether or not the set has no elements in common with the given set.
+func (s Set[E]) IsDisjoint(s2 Set[E]) bool {
 	for v := range s {
 		if s2.Contains(v) {
-			result.Add(v)
+			return false
 		}
 	}
-	return result
+	return true
+}
+
+// IsSubset returns whether or not every element in the set is also in the given set.
+func (s Set[E]) IsSubset(s2 Set[E]) bool {
+	if len(s) > len(s2) {
+		return false
+	}
+	for v := range s {
+		if !s2.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns whether or not the set contains every element in the given set.
+func (s Set[E]) IsSuperset(s2 Set[E]) bool {
+	return s2.IsSubset(s)
+}
+
+// Len returns the number of elements in the set.
+func (s Set[E]) Len() int {
+	return len(s)
 }
 
 // Members returns the elements in the set as a slice.
@@ -51,6 +160,27 @@ func (s Set[E]) Members() []E {
 	return members
 }
 
+// Pop removes and returns an arbitrary element from the set.
+//
+// If the set is empty, the zero value and false are returned.
+func (s Set[E]) Pop() (E, bool) {
+	for v := range s {
+		delete(s, v)
+		return v, true
+	}
+	var zero E
+	return zero, false
+}
+
+// Remove one or more values from the set.
+//
+// Values which do not exist in the set are ignored.
+func (s Set[E]) Remove(vals ...E) {
+	for _, v := range vals {
+		delete(s, v)
+	}
+}
+
 // String returns the set formatted as a string.
 func (s Set[E]) String() string {
 	return fmt.Sprintf("%v", s.Members())
@@ -58,7 +188,12 @@ func (s Set[E]) String() string {
 
 // Union returns a new set which is a union of the current set and the given set.
 func (s Set[E]) Union(s2 Set[E]) Set[E] {
-	result := NewSet(s.Members()...)
-	result.Add(s2.Members()...)
+	result := make(Set[E], max(len(s), len(s2)))
+	for v := range s {
+		result[v] = struct{}{}
+	}
+	for v := range s2 {
+		result[v] = struct{}{}
+	}
 	return result
 }
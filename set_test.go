@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"slices"
 	"testing"
 
 	"go.innotegrity.dev/types"
@@ -19,3 +20,128 @@ func TestSet1(t *testing.T) {
 	t.Logf("matching languages: %s", requiredLangs.Intersection(knownLangs))
 	t.Logf("is Python known: %t", knownLangs.Contains("python"))
 }
+
+func TestSet2(t *testing.T) {
+	granted := types.NewSet("read", "write", "delete")
+	required := types.NewSet("read", "write")
+	if !required.IsSubset(granted) || !granted.IsSuperset(required) {
+		t.Errorf("expected %s to be a subset of %s", required, granted)
+	}
+	if granted.IsSubset(required) {
+		t.Errorf("expected %s not to be a subset of %s", granted, required)
+	}
+	if !required.Equal(types.NewSet("write", "read")) || required.Equal(granted) {
+		t.Errorf("unexpected equality results for %s", required)
+	}
+	if !required.IsDisjoint(types.NewSet("admin")) || required.IsDisjoint(granted) {
+		t.Errorf("unexpected disjoint results for %s", required)
+	}
+}
+
+func TestSet3(t *testing.T) {
+	pending := types.NewSet(1, 2, 3, 4, 5)
+	pending.Remove(1, 2, 42)
+	if pending.Len() != 3 {
+		t.Errorf("expected 3 elements but got %d: %s", pending.Len(), pending)
+	}
+	if !pending.Discard(3) || pending.Discard(3) {
+		t.Errorf("expected only the first discard of 3 to report it was present")
+	}
+	v, ok := pending.Pop()
+	if !ok || pending.Contains(v) || pending.Len() != 1 {
+		t.Errorf("unexpected pop result %d (%t): %s", v, ok, pending)
+	}
+	pending.Clear()
+	if _, ok := pending.Pop(); ok || pending.Len() != 0 {
+		t.Errorf("expected set to be empty but got: %s", pending)
+	}
+}
+
+func TestSet4(t *testing.T) {
+	tags := types.NewSet("prod", "api", "eu-west")
+	count := 0
+	for range tags.All() {
+		count++
+	}
+	if count != tags.Len() {
+		t.Errorf("expected %d elements from iterator but got %d", tags.Len(), count)
+	}
+	sorted := slices.Collect(types.SetSorted(tags))
+	if !slices.Equal(sorted, []string{"api", "eu-west", "prod"}) {
+		t.Errorf("unexpected sorted elements: %v", sorted)
+	}
+}
+
+func TestSet5(t *testing.T) {
+	original := types.NewSet("a", "b")
+	clone := original.Clone()
+	clone.Add("c")
+	original.Remove("a")
+	if !clone.Equal(types.NewSet("a", "b", "c")) || !original.Equal(types.NewSet("b")) {
+		t.Errorf("expected clone to be independent: original=%s clone=%s", original, clone)
+	}
+}
+
+func TestSet6(t *testing.T) {
+	owners := map[string]string{"api": "alice", "web": "bob", "worker": "alice"}
+	if services := types.SetFromKeys(owners); !services.Equal(types.NewSet("api", "web", "worker")) {
+		t.Errorf("unexpected set from keys: %s", services)
+	}
+	if people := types.SetFromValues(owners); !people.Equal(types.NewSet("alice", "bob")) {
+		t.Errorf("unexpected set from values: %s", people)
+	}
+	lengths := types.SetFromSliceFunc([]string{"go", "js", "rust"}, func(s string) int { return len(s) })
+	if !lengths.Equal(types.NewSet(2, 4)) {
+		t.Errorf("unexpected set from slice: %s", lengths)
+	}
+}
+
+func TestSet7(t *testing.T) {
+	fifo := types.NewCappedSet[string](2, types.EvictFIFO)
+	fifo.Add("a", "b")
+	fifo.Contains("a")
+	fifo.Add("c")
+	if fifo.Contains("a") || !fifo.Contains("b") || fifo.Len() != 2 {
+		t.Errorf("expected oldest element to be evicted but got: %s", fifo)
+	}
+
+	lru := types.NewCappedSet[string](2, types.EvictLRU)
+	lru.Add("a", "b")
+	lru.Contains("a")
+	lru.Add("c")
+	if !lru.Contains("a") || lru.Contains("b") || lru.Len() != 2 {
+		t.Errorf("expected least recently used element to be evicted but got: %s", lru)
+	}
+	t.Logf("%s set: %s", types.EvictLRU, lru)
+}
+
+func BenchmarkSetIntersection(b *testing.B) {
+	large, small := types.NewSet[int](), types.NewSet[int]()
+	for i := 0; i < 100000; i++ {
+		large.Add(i)
+	}
+	for i := 0; i < 1000; i++ {
+		small.Add(i * 2)
+	}
+	b.Run("large-small", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			large.Intersection(small)
+		}
+	})
+	b.Run("large-large", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			large.Intersection(large)
+		}
+	})
+}
+
+func BenchmarkSetUnion(b *testing.B) {
+	s1, s2 := types.NewSet[int](), types.NewSet[int]()
+	for i := 0; i < 100000; i++ {
+		s1.Add(i)
+		s2.Add(i + 50000)
+	}
+	for i := 0; i < b.N; i++ {
+		s1.Union(s2)
+	}
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,91 +27,299 @@ import (
 //	tib = size is in tebibytes (where 1gib = 1024^4 bytes)
 //	p | pb = size is in petabytes (where 1p = 1000^5 bytes)
 //	pib = size is in pebibytes (where 1pib = 1024^5 bytes)
+//
+// Sizes may also be given in bits, which is useful for network bandwidth, by adding "bit" or "bits" to any of the
+// prefixes above (e.g. "100mbit" or "1gibit"). Sizes in bits are converted to bytes, where 1 byte = 8 bits. All
+// suffixes are case-insensitive, so a short suffix such as "Mb" or "Gb" always means bytes rather than bits.
 type Size float64
 
+// Common sizes which can be used to convert an integer number of units into a [Size] object, e.g. 5 * types.Mebibyte.
+const (
+	Byte     Size = 1
+	Kilobyte Size = 1000 * Byte
+	Megabyte Size = 1000 * Kilobyte
+	Gigabyte Size = 1000 * Megabyte
+	Terabyte Size = 1000 * Gigabyte
+	Petabyte Size = 1000 * Terabyte
+	Kibibyte Size = 1024 * Byte
+	Mebibyte Size = 1024 * Kibibyte
+	Gibibyte Size = 1024 * Mebibyte
+	Tebibyte Size = 1024 * Gibibyte
+	Pebibyte Size = 1024 * Tebibyte
+)
+
+var (
+	// ErrSizeDivideByZero is returned by [Size.Div] when dividing by zero.
+	ErrSizeDivideByZero = errors.New("size cannot be divided by zero")
+
+	// ErrSizeNegative is returned by the [Size] arithmetic functions when the result would be negative.
+	ErrSizeNegative = errors.New("size cannot be negative")
+
+	// ErrSizeOverflow is returned by the [Size] arithmetic functions when the result would not fit in a 64-bit
+	// integer.
+	ErrSizeOverflow = errors.New("size exceeds maximum size of a 64-bit integer")
+)
+
+// SizeUnits identifies the style of units used when formatting a [Size] object.
+type SizeUnits int
+
+const (
+	// SizeUnitsDecimal formats sizes using decimal units such as KB and MB (where 1KB = 1000 bytes).
+	SizeUnitsDecimal SizeUnits = iota
+
+	// SizeUnitsIEC formats sizes using binary units such as KiB and MiB (where 1KiB = 1024 bytes).
+	SizeUnitsIEC
+)
+
+// SizeFormatOptions holds the settings used when formatting a [Size] object as a string.
+type SizeFormatOptions struct {
+	// DecimalSeparator is the string used to separate the whole and fractional parts of the value, such as "," for
+	// many European locales. If empty, "." is used.
+	DecimalSeparator string `json:"decimal_separator" yaml:"decimal_separator" mapstructure:"decimal_separator"`
+
+	// Precision is the number of digits to display after the decimal point. If negative, the smallest number of
+	// digits necessary to represent the value exactly is used.
+	Precision int `json:"precision" yaml:"precision" mapstructure:"precision"`
+
+	// Space indicates if a space should be placed between the value and the unit, e.g. "1.5 MB" rather than "1.5MB".
+	Space bool `json:"space" yaml:"space" mapstructure:"space"`
+
+	// Units is the style of units to use.
+	Units SizeUnits `json:"units" yaml:"units" mapstructure:"units"`
+}
+
+// DefaultSizeFormat holds the settings used by [Size.String] and when marshaling a [Size] object.
+//
+// It should only be changed during program initialization since it is not safe to change it while sizes are being
+// formatted in other goroutines.
+var DefaultSizeFormat = SizeFormatOptions{Precision: -1, Units: SizeUnitsDecimal}
+
+// sizeUnitNames holds the names of the units for each [SizeUnits] style, starting with kilobytes.
+var sizeUnitNames = map[SizeUnits][]string{
+	SizeUnitsDecimal: {"KB", "MB", "GB", "TB", "PB"},
+	SizeUnitsIEC:     {"KiB", "MiB", "GiB", "TiB", "PiB"},
+}
+
+// sizeBitsPattern matches a size which is given in bits rather than bytes.
+var sizeBitsPattern = regexp.MustCompile(`^(\d*\.\d+|\d+\.\d*|\d+)\s*((?i)[kmgtp]i?)?(?i:bits?)$`)
+
+// sizeBitMultipliers holds the number of bits represented by each of the prefixes which may be used with bit units.
+var sizeBitMultipliers = map[string]float64{
+	"":   1,
+	"k":  1000,
+	"ki": 1024,
+	"m":  1000000,
+	"mi": 1048576,
+	"g":  1000000000,
+	"gi": 1073741824,
+	"t":  1000000000000,
+	"ti": 1099511627776,
+	"p":  1000000000000000,
+	"pi": 1125899906842624,
+}
+
+// sizeMultipliers holds the number of bytes represented by each of the suffixes which may be used with byte units.
+var sizeMultipliers = map[string]float64{
+	"b":     1,
+	"bytes": 1,
+	"k":     1000,
+	"kb":    1000,
+	"kib":   1024,
+	"m":     1000000,
+	"mb":    1000000,
+	"mib":   1048576,
+	"g":     1000000000,
+	"gb":    1000000000,
+	"gib":   1073741824,
+	"t":     1000000000000,
+	"tb":    1000000000000,
+	"tib":   1099511627776,
+	"p":     1000000000000000,
+	"pb":    1000000000000000,
+	"pib":   1125899906842624,
+}
+
+// sizePattern matches a size which is given in bytes with a suffix.
+var sizePattern = regexp.MustCompile(
+	`^(\d*\.\d+|\d+\.\d*|\d+)(\s*?)(?i)(b|bytes|k|kb|kib|m|mb|mib|g|gb|gib|t|tb|tib|p|pb|pib)$`)
+
+// sizeThousandsPattern matches an integer which uses commas to separate groups of thousands, such as "1,500".
+var sizeThousandsPattern = regexp.MustCompile(`^\d{1,3}(,\d{3})+$`)
+
 // ParseSize parses the given string into a [Size] object.
 //
+// Leading and trailing whitespace is ignored, as is any whitespace between the value and the suffix. The value may
+// contain underscores or commas to make it easier to read, e.g. "1_500_000" or "1,500 MB". Commas must separate
+// groups of 3 digits.
+//
 // If an empty string is supplied, 0 is returned.
 func ParseSize(size string) (Size, error) {
+	original := size
+	size = strings.TrimSpace(size)
+
 	// empty size
 	if size == "" {
 		return 0, nil
 	}
+	if strings.ContainsAny(size, "_,") {
+		var err error
+		if size, err = removeSizeSeparators(size); err != nil {
+			return 0, fmt.Errorf("failed to parse size '%s': %w", original, err)
+		}
+	}
+
+	// fast path for the most common formats, such as "1024", "100MB" or "1.5GiB"
+	if parsedSize, ok, err := parseSizeFast(size); ok {
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse size '%s': %w", original, err)
+		}
+		return parsedSize, nil
+	}
+
+	if matches := sizeBitsPattern.FindStringSubmatch(size); matches != nil {
+		parsedSize, err := parseSizeUnits(matches[1], sizeBitMultipliers[strings.ToLower(matches[2])]/8)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse size '%s': %w", original, err)
+		}
+		return parsedSize, nil
+	}
 
-	var parsedSize Size
-	sizePattern := regexp.MustCompile(
-		`^(\d*\.\d+|\d+\.\d*|\d+)(\s*?)(?i)(b|bytes|k|kb|kib|m|mb|mib|g|gb|gib|t|tb|tib|p|pb|pib)$`)
 	matches := sizePattern.FindStringSubmatch(size)
 	if matches == nil {
 		ival64, err := strconv.ParseInt(size, 10, 64)
 		if err != nil {
-			return 0, fmt.Errorf("failed to parse size '%s': %w", size, err)
+			return 0, fmt.Errorf("failed to parse size '%s': %w", original, err)
 		}
-		parsedSize = Size(ival64)
-		return parsedSize, nil
+		return Size(ival64), nil
 	}
+	parsedSize, err := parseSizeUnits(matches[1], sizeMultipliers[strings.ToLower(matches[3])])
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse size '%s': %w", original, err)
+	}
+	return parsedSize, nil
+}
 
-	// NOTE: we parse as a float since the value may contain a decimal point - any decimal place digits
-	// left over will be discarded
-	fval64, err := strconv.ParseFloat(matches[1], 64)
+// ParseSignedSize parses the given string, which may begin with a "+" or "-" sign, into a [Size] object.
+//
+// This is useful for values which represent a change in size, such as "-500MB" to reduce a quota. Apart from the
+// sign, the string is parsed in the same way as [ParseSize]. Use [Size.Validate] to reject negative values where they
+// do not make sense.
+func ParseSignedSize(size string) (Size, error) {
+	str := strings.TrimSpace(size)
+	sign := Size(1)
+	if strings.HasPrefix(str, "-") {
+		sign, str = -1, str[1:]
+	} else {
+		str = strings.TrimPrefix(str, "+")
+	}
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		return 0, fmt.Errorf("failed to parse size '%s': more than one sign was given", size)
+	}
+	parsedSize, err := ParseSize(str)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse size '%s': %w", size, err)
+		return 0, err
 	}
-	switch strings.ToLower(matches[3]) {
-	case "k", "kb":
-		if fval64 > (math.MaxFloat64 / 1000) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1000)
-	case "kib":
-		if fval64 > (math.MaxFloat64 / 1024) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1024)
-	case "m", "mb":
-		if fval64 > (math.MaxFloat64 / 1000000) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1000000)
-	case "mib":
-		if fval64 > (math.MaxFloat64 / 1048576) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1048576)
-	case "g", "gb":
-		if fval64 > (math.MaxFloat64 / 1000000000) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1000000000)
-	case "gib":
-		if fval64 > (math.MaxFloat64 / 1073741824) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1073741824)
-	case "t", "tb":
-		if fval64 > (math.MaxFloat64 / 1000000000000) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1000000000000)
-	case "tib":
-		if fval64 > (math.MaxFloat64 / 1099511627776) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1099511627776)
-	case "p", "pb":
-		if fval64 > (math.MaxFloat64 / 1000000000000000) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
-		}
-		parsedSize = Size(fval64 * 1000000000000000)
-	case "pib":
-		if fval64 > (math.MaxFloat64 / 1125899906842624) {
-			return 0, errors.New("size exceeds maximum size of a 64-bit integer")
+	return sign * parsedSize, nil
+}
+
+// Abs returns the absolute value of the size.
+func (s Size) Abs() Size {
+	return Size(math.Abs(float64(s)))
+}
+
+// Add returns the sum of the size and the given size.
+//
+// If the result would be negative, [ErrSizeNegative] is returned. If it would not fit in a 64-bit integer,
+// [ErrSizeOverflow] is returned.
+func (s Size) Add(s2 Size) (Size, error) {
+	return checkSize(s + s2)
+}
+
+// Bytes returns the size as a whole number of bytes.
+//
+// Any fractional bytes are discarded.
+func (s Size) Bytes() int64 {
+	return int64(s)
+}
+
+// Cmp compares the size to the given size, returning -1 if it is smaller, 0 if they are equal and +1 if it is larger.
+func (s Size) Cmp(s2 Size) int {
+	return cmp.Compare(s, s2)
+}
+
+// Div returns the size divided by n.
+//
+// If n is 0, [ErrSizeDivideByZero] is returned. If the result would be negative, [ErrSizeNegative] is returned.
+func (s Size) Div(n int64) (Size, error) {
+	if n == 0 {
+		return 0, ErrSizeDivideByZero
+	}
+	return checkSize(s / Size(n))
+}
+
+// Format returns the [Size] object as a string using the given options.
+//
+// The largest unit for which the value is at least 1 is used, e.g. 1500000 is formatted as "1.5MB" with decimal units
+// or "1.430511474609375MiB" with IEC units. Values less than 1 kilobyte are formatted in bytes, in which case whole
+// numbers of bytes are never given a fractional part. Negative values are formatted with a leading "-".
+func (s Size) Format(opts SizeFormatOptions) string {
+	if s < 0 {
+		return "-" + (-s).Format(opts)
+	}
+	base, units := Size(1000), sizeUnitNames[SizeUnitsDecimal]
+	if opts.Units == SizeUnitsIEC {
+		base, units = 1024, sizeUnitNames[SizeUnitsIEC]
+	}
+	if s < base {
+		if s == Size(s.Bytes()) {
+			opts.Precision = -1
 		}
-		parsedSize = Size(fval64 * 1125899906842624)
-	default:
-		parsedSize = Size(fval64)
+		return formatSizeValue(float64(s), opts) + " bytes"
 	}
-	return parsedSize, nil
+	divisor, i := base, 0
+	for ; i < len(units)-1 && s >= divisor*base; i++ {
+		divisor *= base
+	}
+	unit := units[i]
+	if opts.Space {
+		unit = " " + unit
+	}
+	return formatSizeValue(float64(s)/float64(divisor), opts) + unit
+}
+
+// Gibibytes returns the size as a number of gibibytes (1024^3 bytes).
+func (s Size) Gibibytes() float64 {
+	return float64(s / Gibibyte)
+}
+
+// Gigabytes returns the size as a number of gigabytes (1000^3 bytes).
+func (s Size) Gigabytes() float64 {
+	return float64(s / Gigabyte)
+}
+
+// Humanize returns the [Size] object as a string suitable for display to users, such as "1.46 GiB".
+//
+// The value is rounded to the given number of digits after the decimal point and a space is placed between the value
+// and the unit. Any other settings, such as the units and decimal separator, are taken from opts.
+func (s Size) Humanize(precision int, opts SizeFormatOptions) string {
+	opts.Precision = precision
+	opts.Space = true
+	return s.Format(opts)
+}
+
+// IsNegative returns whether or not the size is less than 0.
+func (s Size) IsNegative() bool {
+	return s < 0
+}
+
+// Kibibytes returns the size as a number of kibibytes (1024 bytes).
+func (s Size) Kibibytes() float64 {
+	return float64(s / Kibibyte)
+}
+
+// Kilobytes returns the size as a number of kilobytes (1000 bytes).
+func (s Size) Kilobytes() float64 {
+	return float64(s / Kilobyte)
 }
 
 // MarshalJSON marshals the [Size] object to JSON.
@@ -123,29 +332,100 @@ func (s Size) MarshalText() ([]byte, error) {
 	return []byte(s.String()), nil
 }
 
-// String returns the [Size] object as a string.
-func (s Size) String() string {
-	if s < 1000 {
-		return fmt.Sprintf("%g bytes", s)
-	}
-	if s < 1000000 {
-		return fmt.Sprintf("%gKB", float64(s)/float64(1000))
-	}
-	if s < 1000000000 {
-		return fmt.Sprintf("%gMB", float64(s)/float64(1000000))
-	}
-	if s < 1000000000000 {
-		return fmt.Sprintf("%gGB", float64(s)/float64(1000000000))
-	}
-	if s < 1000000000000000 {
-		return fmt.Sprintf("%gTB", float64(s)/float64(1000000000000))
+// Max returns the larger of the size and the given size.
+func (s Size) Max(s2 Size) Size {
+	return max(s, s2)
+}
+
+// Mebibytes returns the size as a number of mebibytes (1024^2 bytes).
+func (s Size) Mebibytes() float64 {
+	return float64(s / Mebibyte)
+}
+
+// Megabytes returns the size as a number of megabytes (1000^2 bytes).
+func (s Size) Megabytes() float64 {
+	return float64(s / Megabyte)
+}
+
+// Min returns the smaller of the size and the given size.
+func (s Size) Min(s2 Size) Size {
+	return min(s, s2)
+}
+
+// MulInt returns the size multiplied by n.
+//
+// If the result would be negative, [ErrSizeNegative] is returned. If it would not fit in a 64-bit integer,
+// [ErrSizeOverflow] is returned.
+func (s Size) MulInt(n int64) (Size, error) {
+	return checkSize(s * Size(n))
+}
+
+// Pebibytes returns the size as a number of pebibytes (1024^5 bytes).
+func (s Size) Pebibytes() float64 {
+	return float64(s / Pebibyte)
+}
+
+// Petabytes returns the size as a number of petabytes (1000^5 bytes).
+func (s Size) Petabytes() float64 {
+	return float64(s / Petabyte)
+}
+
+// Set parses the given string into the [Size] object.
+//
+// This allows a [Size] object to be used as a command-line flag with the standard [flag] package as well as with
+// github.com/spf13/pflag:
+//
+//	maxUpload := 100 * types.Mebibyte
+//	flag.Var(&maxUpload, "max-upload", "maximum upload size")
+//
+// The value is parsed by [ParseSignedSize], so use [Size.Validate] to reject negative sizes.
+func (s *Size) Set(value string) error {
+	size, err := ParseSignedSize(value)
+	if err != nil {
+		return err
 	}
-	return fmt.Sprintf("%gPB", float64(s)/float64(1000000000000000))
+	*s = size
+	return nil
+}
+
+// String returns the [Size] object as a string using the [DefaultSizeFormat] settings.
+func (s Size) String() string {
+	return s.Format(DefaultSizeFormat)
+}
+
+// StringIEC returns the [Size] object as a string using binary units such as KiB and MiB.
+//
+// The precision is taken from the [DefaultSizeFormat] settings.
+func (s Size) StringIEC() string {
+	return s.Format(SizeFormatOptions{Precision: DefaultSizeFormat.Precision, Units: SizeUnitsIEC})
+}
+
+// Sub returns the difference between the size and the given size.
+//
+// If the result would be negative, [ErrSizeNegative] is returned.
+func (s Size) Sub(s2 Size) (Size, error) {
+	return checkSize(s - s2)
+}
+
+// Tebibytes returns the size as a number of tebibytes (1024^4 bytes).
+func (s Size) Tebibytes() float64 {
+	return float64(s / Tebibyte)
+}
+
+// Terabytes returns the size as a number of terabytes (1000^4 bytes).
+func (s Size) Terabytes() float64 {
+	return float64(s / Terabyte)
+}
+
+// Type returns the name of the type for use in command-line help output by github.com/spf13/pflag.
+func (s *Size) Type() string {
+	return "size"
 }
 
 // UnmarshalJSON parses the JSON data into a [Size] object.
 //
-// If an empty string is supplied, 0 is stored.
+// Strings are parsed by [ParseSignedSize] so that negative sizes can be read back after they are marshaled. Use
+// [Size.Validate] to reject them. If an empty string is supplied, 0 is stored.
 func (s *Size) UnmarshalJSON(data []byte) error {
 	var fval64 float64
 	if err := json.Unmarshal(data, &fval64); err == nil {
@@ -157,7 +437,7 @@ func (s *Size) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &sval); err != nil {
 		return err
 	}
-	size, err := ParseSize(sval)
+	size, err := ParseSignedSize(sval)
 	if err != nil {
 		return err
 	}
@@ -167,12 +447,149 @@ func (s *Size) UnmarshalJSON(data []byte) error {
 
 // UnmarshalText parses the text into a [Size] object.
 //
-// If an empty string is supplied, 0 is stored.
+// The text is parsed by [ParseSignedSize] so that negative sizes can be read back after they are marshaled. Use
+// [Size.Validate] to reject them. If an empty string is supplied, 0 is stored.
 func (s *Size) UnmarshalText(data []byte) error {
-	size, err := ParseSize(string(data))
+	size, err := ParseSignedSize(string(data))
 	if err != nil {
 		return err
 	}
 	*s = size
 	return nil
 }
+
+// Validate returns [ErrSizeNegative] if the size is less than 0.
+//
+// This can be used to reject negative sizes where they do not make sense, since they are accepted by
+// [ParseSignedSize] and when a [Size] object is unmarshaled or set as a command-line flag.
+func (s Size) Validate() error {
+	if s < 0 {
+		return ErrSizeNegative
+	}
+	return nil
+}
+
+// SizeBytes is a [Size] which is marshaled as a plain number of bytes rather than a human-readable string.
+//
+// It is parsed in exactly the same way as a [Size] object, so either numbers or strings such as "1.5GB" are accepted
+// when unmarshaling. Use it for values which are consumed by other systems that expect numbers.
+type SizeBytes Size
+
+// MarshalJSON marshals the [SizeBytes] object to JSON as a whole number of bytes.
+func (s SizeBytes) MarshalJSON() ([]byte, error) {
+	return s.MarshalText()
+}
+
+// MarshalText marshals the [SizeBytes] object to plain text as a whole number of bytes.
+func (s SizeBytes) MarshalText() ([]byte, error) {
+	return strconv.AppendInt(nil, Size(s).Bytes(), 10), nil
+}
+
+// String returns the [SizeBytes] object as a human-readable string.
+func (s SizeBytes) String() string {
+	return Size(s).String()
+}
+
+// UnmarshalJSON parses the JSON data into a [SizeBytes] object.
+//
+// If an empty string is supplied, 0 is stored.
+func (s *SizeBytes) UnmarshalJSON(data []byte) error {
+	return (*Size)(s).UnmarshalJSON(data)
+}
+
+// UnmarshalText parses the text into a [SizeBytes] object.
+//
+// If an empty string is supplied, 0 is stored.
+func (s *SizeBytes) UnmarshalText(data []byte) error {
+	return (*Size)(s).UnmarshalText(data)
+}
+
+// checkSize returns the given size as long as it is neither negative nor too large to fit in a 64-bit integer.
+func checkSize(s Size) (Size, error) {
+	if s < 0 {
+		return 0, ErrSizeNegative
+	}
+	if s >= math.MaxInt64 {
+		return 0, ErrSizeOverflow
+	}
+	return s, nil
+}
+
+// formatSizeValue formats the numeric part of a size with the given precision and decimal separator.
+func formatSizeValue(v float64, opts SizeFormatOptions) string {
+	str := fmt.Sprintf("%g", v)
+	if opts.Precision >= 0 {
+		str = strconv.FormatFloat(v, 'f', opts.Precision, 64)
+	}
+	if opts.DecimalSeparator != "" {
+		str = strings.Replace(str, ".", opts.DecimalSeparator, 1)
+	}
+	return str
+}
+
+// parseSizeFast parses sizes which consist of digits, an optional decimal point and an optional byte suffix without
+// using regular expressions.
+//
+// If the size is not in one of those formats, false is returned so that it can be parsed by [ParseSize] instead.
+func parseSizeFast(size string) (Size, bool, error) {
+	end, dots := 0, 0
+	for ; end < len(size); end++ {
+		if size[end] == '.' {
+			dots++
+		} else if size[end] < '0' || size[end] > '9' {
+			break
+		}
+	}
+	if end == dots || dots > 1 {
+		return 0, false, nil
+	}
+	if end == len(size) {
+		if dots > 0 {
+			return 0, false, nil
+		}
+		ival64, err := strconv.ParseInt(size, 10, 64)
+		return Size(ival64), err == nil, nil
+	}
+	multiplier, ok := sizeMultipliers[size[end:]]
+	if !ok {
+		multiplier, ok = sizeMultipliers[strings.ToLower(size[end:])]
+	}
+	if !ok {
+		return 0, false, nil
+	}
+	parsedSize, err := parseSizeUnits(size[:end], multiplier)
+	return parsedSize, true, err
+}
+
+// parseSizeUnits converts the given value from units which are each the given number of bytes into a [Size] object.
+//
+// NOTE: we parse as a float since the value may contain a decimal point - any decimal place digits left over will be
+// discarded
+func parseSizeUnits(value string, multiplier float64) (Size, error) {
+	fval64, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if multiplier > 1 && fval64 > (math.MaxFloat64/multiplier) {
+		return 0, errors.New("size exceeds maximum size of a 64-bit integer")
+	}
+	return Size(fval64 * multiplier), nil
+}
+
+// removeSizeSeparators removes any underscores and thousands separators from the numeric part of the size.
+func removeSizeSeparators(size string) (string, error) {
+	size = strings.ReplaceAll(size, "_", "")
+	if !strings.Contains(size, ",") {
+		return size, nil
+	}
+	end := strings.IndexFunc(size, func(r rune) bool {
+		return (r < '0' || r > '9') && r != ','
+	})
+	if end < 0 {
+		end = len(size)
+	}
+	if !sizeThousandsPattern.MatchString(size[:end]) {
+		return "", errors.New("commas must separate groups of 3 digits")
+	}
+	return strings.ReplaceAll(size[:end], ",", "") + size[end:], nil
+}
@@ -0,0 +1,200 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+// TODO: implement additional testing and benchmarks
+
+func TestSize1(t *testing.T) {
+	sizes := map[types.Size][3]string{
+		512:        {"512 bytes", "512 bytes", "512 bytes"},
+		1500000:    {"1.5MB", "1.430511474609375MiB", "1.43MiB"},
+		1073741824: {"1.073741824GB", "1GiB", "1.00GiB"},
+	}
+	for size, expected := range sizes {
+		formatted := [3]string{
+			size.String(),
+			size.StringIEC(),
+			size.Format(types.SizeFormatOptions{Precision: 2, Units: types.SizeUnitsIEC}),
+		}
+		if formatted != expected {
+			t.Errorf("expected %v but got %v", expected, formatted)
+		}
+		t.Logf("size: %v", formatted)
+	}
+}
+
+func TestSize2(t *testing.T) {
+	size := 3*types.Gibibyte + 512*types.Mebibyte
+	if size.Gibibytes() != 3.5 || size.Bytes() != 3758096384 {
+		t.Errorf("unexpected unit values for %s: %g GiB, %d bytes", size, size.Gibibytes(), size.Bytes())
+	}
+	t.Logf("size: %gMB, %gMiB", size.Megabytes(), size.Mebibytes())
+}
+
+func TestSize3(t *testing.T) {
+	total, err := types.Size(0).Add(750 * types.Megabyte)
+	if err != nil {
+		t.Fatalf("failed to add sizes: %v", err)
+	}
+	if total, err = total.MulInt(4); err != nil || total != 3*types.Gigabyte {
+		t.Errorf("unexpected product: %s (%v)", total, err)
+	}
+	if _, err := total.Sub(4 * types.Gigabyte); !errors.Is(err, types.ErrSizeNegative) {
+		t.Errorf("expected negative result to fail but got: %v", err)
+	}
+	if _, err := types.Pebibyte.MulInt(1 << 20); !errors.Is(err, types.ErrSizeOverflow) {
+		t.Errorf("expected overflow to fail but got: %v", err)
+	}
+	if _, err := total.Div(0); !errors.Is(err, types.ErrSizeDivideByZero) {
+		t.Errorf("expected divide by zero to fail but got: %v", err)
+	}
+	if total.Cmp(types.Gigabyte) != 1 || total.Min(types.Gigabyte) != types.Gigabyte {
+		t.Errorf("unexpected comparison results for %s", total)
+	}
+}
+
+func TestSize4(t *testing.T) {
+	sizes := map[string]types.Size{
+		"1,500 MB":      1500 * types.Megabyte,
+		"1_500_000":     1500000,
+		"  .5GB\t":      500 * types.Megabyte,
+		"2 KiB":         2 * types.Kibibyte,
+		"1,234,567 b":   1234567,
+		"10_000kb":      10 * types.Megabyte,
+		" 1.5  gib ":    1536 * types.Mebibyte,
+		"12,345,678.5k": 12345678500,
+	}
+	for str, expected := range sizes {
+		size, err := types.ParseSize(str)
+		if err != nil {
+			t.Errorf("failed to parse size: %v", err)
+		} else if size != expected {
+			t.Errorf("expected '%s' to be %s but got %s", str, expected, size)
+		}
+	}
+	for _, str := range []string{"1,5GB", "15,00", "1,,500"} {
+		if _, err := types.ParseSize(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
+
+func TestSize5(t *testing.T) {
+	sizes := map[string]types.Size{
+		"100Mb":   100 * types.Megabyte,
+		"100Mbit": 12500000,
+		"100MB":   100 * types.Megabyte,
+		"100mb":   100 * types.Megabyte,
+		"1gbit":   125 * types.Megabyte,
+		"8 Kibit": types.Kibibyte,
+		"64 bits": 8,
+	}
+	for str, expected := range sizes {
+		size, err := types.ParseSize(str)
+		if err != nil {
+			t.Errorf("failed to parse size: %v", err)
+		} else if size != expected {
+			t.Errorf("expected '%s' to be %s but got %s", str, expected, size)
+		}
+	}
+}
+
+func TestSize6(t *testing.T) {
+	var config struct {
+		Limit types.SizeBytes `json:"limit"`
+	}
+	if err := json.Unmarshal([]byte(`{"limit": "1.5GB"}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal size: %v", err)
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("failed to marshal size: %v", err)
+	}
+	if string(data) != `{"limit":1500000000}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestSize7(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	maxUpload := 10 * types.Mebibyte
+	fs.Var(&maxUpload, "max-upload", "maximum upload size")
+	if err := fs.Parse([]string{"--max-upload=100MiB"}); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	if maxUpload != 100*types.Mebibyte {
+		t.Errorf("unexpected flag value: %s", maxUpload)
+	}
+}
+
+func TestSize8(t *testing.T) {
+	size := 1572864000 * types.Byte
+	if str := size.Humanize(2, types.SizeFormatOptions{Units: types.SizeUnitsIEC}); str != "1.46 GiB" {
+		t.Errorf("unexpected humanized size: %s", str)
+	}
+	if str := size.Humanize(1, types.SizeFormatOptions{DecimalSeparator: ","}); str != "1,6 GB" {
+		t.Errorf("unexpected humanized size: %s", str)
+	}
+}
+
+func TestSize9(t *testing.T) {
+	delta, err := types.ParseSignedSize("-500MB")
+	if err != nil {
+		t.Fatalf("failed to parse signed size: %v", err)
+	}
+	if delta.String() != "-500MB" || !delta.IsNegative() {
+		t.Errorf("unexpected signed size: %s", delta)
+	}
+	if err := delta.Validate(); !errors.Is(err, types.ErrSizeNegative) {
+		t.Errorf("expected negative size to fail validation but got: %v", err)
+	}
+	if _, err := types.ParseSignedSize("--5MB"); err == nil {
+		t.Errorf("expected multiple signs to fail")
+	}
+}
+
+func TestSize10(t *testing.T) {
+	// negative sizes can be read back after they are marshaled
+	delta := -500 * types.Megabyte
+	data, err := json.Marshal(delta)
+	if err != nil {
+		t.Fatalf("failed to marshal size: %v", err)
+	}
+	var size types.Size
+	if err := json.Unmarshal(data, &size); err != nil || size != delta {
+		t.Errorf("expected %s to unmarshal to %s but got %s: %v", data, delta, size, err)
+	}
+	text, _ := delta.MarshalText()
+	if err := size.UnmarshalText(text); err != nil || size != delta {
+		t.Errorf("expected %s to unmarshal to %s but got %s: %v", text, delta, size, err)
+	}
+	if err := size.Set("-1.5GB"); err != nil || size != -1500*types.Megabyte {
+		t.Errorf("expected flag to be set to -1.5GB but got %s: %v", size, err)
+	}
+	if err := size.Validate(); !errors.Is(err, types.ErrSizeNegative) {
+		t.Errorf("expected negative size to fail validation but got: %v", err)
+	}
+	if err := size.Set("--5MB"); err == nil {
+		t.Errorf("expected multiple signs to fail")
+	}
+}
+
+func BenchmarkParseSize(b *testing.B) {
+	sizes := []string{"1024", "100MB", "1.5GiB", "1,500 MB", "100Mbit"}
+	for _, str := range sizes {
+		b.Run(str, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := types.ParseSize(str); err != nil {
+					b.Fatalf("failed to parse size: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// snowflakeNodeBits is the number of bits in a [SnowflakeID] used for the node ID.
+	snowflakeNodeBits = 10
+
+	// snowflakeSequenceBits is the number of bits in a [SnowflakeID] used for the sequence number.
+	snowflakeSequenceBits = 12
+
+	// snowflakeMaxNodeID is the largest node ID which can be stored in a [SnowflakeID].
+	snowflakeMaxNodeID = 1<<snowflakeNodeBits - 1
+
+	// snowflakeMaxSequence is the largest sequence number which can be stored in a [SnowflakeID].
+	snowflakeMaxSequence = 1<<snowflakeSequenceBits - 1
+)
+
+// DefaultSnowflakeEpoch is the epoch used by a [SnowflakeGenerator] object if none is configured. It is the epoch
+// used by Twitter's original Snowflake implementation (2010-11-04 01:42:54.657 UTC).
+var DefaultSnowflakeEpoch = time.UnixMilli(1288834974657).UTC()
+
+// SnowflakeID is a 64-bit, time-ordered identifier produced by a [SnowflakeGenerator] object.
+//
+// From the most significant bit, it is made up of an unused sign bit, a 41-bit millisecond timestamp relative to the
+// generator's epoch, a 10-bit node ID and a 12-bit sequence number. It is marshaled to JSON as a string since
+// JavaScript and many other JSON decoders cannot represent integers larger than 2^53 exactly, but it can be
+// unmarshaled from either a string or a number.
+type SnowflakeID int64
+
+// ParseSnowflakeID parses the given decimal string into a [SnowflakeID] object.
+func ParseSnowflakeID(id string) (SnowflakeID, error) {
+	val, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || val < 0 {
+		return 0, fmt.Errorf("failed to parse snowflake ID '%s': expected a non-negative integer", id)
+	}
+	return SnowflakeID(val), nil
+}
+
+// MarshalJSON marshals the [SnowflakeID] object to JSON as a string.
+func (s SnowflakeID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// MarshalText marshals the [SnowflakeID] object to text.
+func (s SnowflakeID) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// NodeID returns the ID of the node which generated the ID.
+func (s SnowflakeID) NodeID() int64 {
+	return int64(s) >> snowflakeSequenceBits & snowflakeMaxNodeID
+}
+
+// Sequence returns the sequence number of the ID within its millisecond.
+func (s SnowflakeID) Sequence() int64 {
+	return int64(s) & snowflakeMaxSequence
+}
+
+// String returns the [SnowflakeID] object as a decimal string.
+func (s SnowflakeID) String() string {
+	return strconv.FormatInt(int64(s), 10)
+}
+
+// Time returns the time at which the ID was generated, given the epoch of the generator which produced it.
+func (s SnowflakeID) Time(epoch time.Time) time.Time {
+	return epoch.Add(time.Duration(int64(s)>>(snowflakeNodeBits+snowflakeSequenceBits)) * time.Millisecond)
+}
+
+// UnmarshalJSON parses the JSON string or number into a [SnowflakeID] object.
+func (s *SnowflakeID) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err == nil {
+		return s.UnmarshalText([]byte(sval))
+	}
+	var ival int64
+	if err := json.Unmarshal(data, &ival); err != nil {
+		return fmt.Errorf("failed to parse snowflake ID '%s': expected a string or integer", string(data))
+	}
+	return s.UnmarshalText([]byte(strconv.FormatInt(ival, 10)))
+}
+
+// UnmarshalText parses the text into a [SnowflakeID] object.
+func (s *SnowflakeID) UnmarshalText(data []byte) error {
+	id, err := ParseSnowflakeID(string(data))
+	if err != nil {
+		return err
+	}
+	*s = id
+	return nil
+}
+
+// SnowflakeOptions holds the options for creating a [SnowflakeGenerator] object.
+type SnowflakeOptions struct {
+	// NodeID uniquely identifies the generator among all generators sharing the same epoch. It must be between 0
+	// and 1023.
+	NodeID int64 `json:"node_id" yaml:"node_id" mapstructure:"node_id"`
+
+	// Epoch is the time from which timestamps are measured. If zero, [DefaultSnowflakeEpoch] is used.
+	Epoch time.Time `json:"epoch" yaml:"epoch" mapstructure:"epoch"`
+
+	// Clock provides the current time when generating IDs. If nil, [SystemClock] is used.
+	Clock Clock `json:"-" yaml:"-" mapstructure:"-"`
+}
+
+// SnowflakeGenerator generates unique, time-ordered [SnowflakeID] objects.
+//
+// Up to 4096 IDs can be generated per millisecond. If more are requested, or if the clock moves backwards, the
+// generator borrows from the following millisecond rather than blocking, so IDs remain unique and increasing but
+// their timestamps may briefly run ahead of the clock. SnowflakeGenerator objects are safe for concurrent use.
+type SnowflakeGenerator struct {
+	last     int64
+	mu       sync.Mutex
+	opts     SnowflakeOptions
+	sequence int64
+}
+
+// NewSnowflakeGenerator creates a new SnowflakeGenerator object with the given options.
+func NewSnowflakeGenerator(opts SnowflakeOptions) (*SnowflakeGenerator, error) {
+	if opts.NodeID < 0 || opts.NodeID > snowflakeMaxNodeID {
+		return nil, fmt.Errorf("snowflake node ID %d must be between 0 and %d", opts.NodeID, snowflakeMaxNodeID)
+	}
+	if opts.Epoch.IsZero() {
+		opts.Epoch = DefaultSnowflakeEpoch
+	}
+	if opts.Clock == nil {
+		opts.Clock = SystemClock{}
+	}
+	return &SnowflakeGenerator{
+		last: -1,
+		opts: opts,
+	}, nil
+}
+
+// Epoch returns the time from which the generator measures timestamps.
+func (g *SnowflakeGenerator) Epoch() time.Time {
+	return g.opts.Epoch
+}
+
+// Next generates a new ID.
+func (g *SnowflakeGenerator) Next() SnowflakeID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := max(g.opts.Clock.Now().Sub(g.opts.Epoch).Milliseconds(), 0)
+	if now > g.last {
+		g.last = now
+		g.sequence = 0
+	} else {
+		g.sequence++
+		if g.sequence > snowflakeMaxSequence {
+			g.last++
+			g.sequence = 0
+		}
+	}
+	return SnowflakeID(g.last<<(snowflakeNodeBits+snowflakeSequenceBits) |
+		g.opts.NodeID<<snowflakeSequenceBits | g.sequence)
+}
@@ -0,0 +1,57 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestSnowflake1(t *testing.T) {
+	clock := &manualClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	gen, err := types.NewSnowflakeGenerator(types.SnowflakeOptions{NodeID: 42, Clock: clock})
+	if err != nil {
+		t.Fatalf("failed to create generator: %v", err)
+	}
+
+	var last types.SnowflakeID
+	for i := 0; i < 5000; i++ {
+		id := gen.Next()
+		if id <= last || id.NodeID() != 42 {
+			t.Fatalf("unexpected ID %d after %d", id, last)
+		}
+		last = id
+	}
+	if got := last.Time(gen.Epoch()); got.Sub(clock.now) != time.Millisecond {
+		t.Errorf("expected sequence overflow to borrow the next millisecond but got %s", got)
+	}
+
+	// moving the clock backwards must not produce duplicate IDs
+	clock.now = clock.now.Add(-time.Second)
+	if id := gen.Next(); id <= last {
+		t.Errorf("expected ID %d to be greater than %d", id, last)
+	}
+
+	clock.now = clock.now.Add(time.Hour)
+	id := gen.Next()
+	if !id.Time(gen.Epoch()).Equal(clock.now) || id.Sequence() != 0 {
+		t.Errorf("unexpected ID time %s or sequence %d", id.Time(gen.Epoch()), id.Sequence())
+	}
+
+	data, _ := json.Marshal(map[string]types.SnowflakeID{"id": id})
+	t.Logf("JSON: %s", data)
+	var decoded struct {
+		ID types.SnowflakeID `json:"id"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.ID != id {
+		t.Errorf("failed to round-trip ID through JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"id": 1234}`), &decoded); err != nil || decoded.ID != 1234 {
+		t.Errorf("failed to unmarshal ID from a number: %v", err)
+	}
+
+	if _, err := types.NewSnowflakeGenerator(types.SnowflakeOptions{NodeID: 1024}); err == nil {
+		t.Errorf("expected node ID 1024 to fail")
+	}
+}
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// absoluteZeroCelsius is absolute zero expressed in degrees Celsius.
+const absoluteZeroCelsius = -273.15
+
+// ErrTemperatureUnitMissing is returned when a temperature is parsed from a number with no unit.
+var ErrTemperatureUnitMissing = errors.New("temperature must include a unit of C, F or K")
+
+// temperaturePattern matches a temperature value followed by an optional unit, such as "22.5C" or "72 °F".
+var temperaturePattern = regexp.MustCompile(`^([+-]?[0-9]*\.?[0-9]+)\s*(?:°\s*)?([A-Za-z]*)$`)
+
+// TemperatureUnit is a unit in which temperatures are expressed.
+type TemperatureUnit int
+
+const (
+	// Celsius is the degrees Celsius temperature unit.
+	Celsius TemperatureUnit = iota
+
+	// Fahrenheit is the degrees Fahrenheit temperature unit.
+	Fahrenheit
+
+	// Kelvin is the kelvin temperature unit.
+	Kelvin
+)
+
+// String returns the [TemperatureUnit] object as its symbol.
+func (u TemperatureUnit) String() string {
+	switch u {
+	case Celsius:
+		return "C"
+	case Fahrenheit:
+		return "F"
+	case Kelvin:
+		return "K"
+	default:
+		return "unknown"
+	}
+}
+
+// Temperature represents a temperature, stored in degrees Celsius.
+//
+// Temperatures are always parsed with an explicit unit so that a value such as "72" is never silently interpreted
+// in the wrong scale.
+type Temperature float64
+
+// NewTemperature creates a new Temperature object from the given value in the given unit.
+func NewTemperature(value float64, unit TemperatureUnit) Temperature {
+	switch unit {
+	case Fahrenheit:
+		return Temperature((value - 32) * 5 / 9)
+	case Kelvin:
+		return Temperature(value + absoluteZeroCelsius)
+	default:
+		return Temperature(value)
+	}
+}
+
+// ParseTemperature parses the given string into a [Temperature] object.
+//
+// The string must be a number followed by a unit, such as "72F", "22.5C", "300K" or "-4 °F". Units are not case
+// sensitive and may also be written as "celsius", "fahrenheit" or "kelvin". Temperatures below absolute zero are
+// rejected.
+func ParseTemperature(temp string) (Temperature, error) {
+	matches := temperaturePattern.FindStringSubmatch(strings.TrimSpace(temp))
+	if matches == nil {
+		return 0, fmt.Errorf("failed to parse temperature '%s': expected a number followed by a unit", temp)
+	}
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse temperature '%s': %w", temp, err)
+	}
+
+	var unit TemperatureUnit
+	switch strings.ToLower(matches[2]) {
+	case "":
+		return 0, fmt.Errorf("failed to parse temperature '%s': %w", temp, ErrTemperatureUnitMissing)
+	case "c", "celsius":
+		unit = Celsius
+	case "f", "fahrenheit":
+		unit = Fahrenheit
+	case "k", "kelvin":
+		unit = Kelvin
+	default:
+		return 0, fmt.Errorf("failed to parse temperature '%s': unknown unit '%s'", temp, matches[2])
+	}
+
+	t := NewTemperature(value, unit)
+	if t < absoluteZeroCelsius {
+		return 0, fmt.Errorf("failed to parse temperature '%s': temperature is below absolute zero", temp)
+	}
+	return t, nil
+}
+
+// Celsius returns the temperature in degrees Celsius.
+func (t Temperature) Celsius() float64 {
+	return float64(t)
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit.
+func (t Temperature) Fahrenheit() float64 {
+	return float64(t)*9/5 + 32
+}
+
+// Format returns the temperature as a string in the given unit with the given number of decimal places, such as
+// "71.6F".
+//
+// A negative precision uses the smallest number of digits necessary to represent the value.
+func (t Temperature) Format(unit TemperatureUnit, precision int) string {
+	var value float64
+	switch unit {
+	case Fahrenheit:
+		value = t.Fahrenheit()
+	case Kelvin:
+		value = t.Kelvin()
+	default:
+		value = t.Celsius()
+	}
+	return strconv.FormatFloat(value, 'f', precision, 64) + unit.String()
+}
+
+// Kelvin returns the temperature in kelvin.
+func (t Temperature) Kelvin() float64 {
+	return float64(t) - absoluteZeroCelsius
+}
+
+// MarshalJSON marshals the [Temperature] object to JSON.
+func (t Temperature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// MarshalText marshals the [Temperature] object to plain text.
+func (t Temperature) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// String returns the [Temperature] object as a string in degrees Celsius, such as "22.5C".
+func (t Temperature) String() string {
+	return t.Format(Celsius, -1)
+}
+
+// UnmarshalJSON parses the JSON data into a [Temperature] object.
+//
+// The data must be a string including a unit. Plain numbers are rejected with [ErrTemperatureUnitMissing].
+func (t *Temperature) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		var fval float64
+		if json.Unmarshal(data, &fval) == nil {
+			return fmt.Errorf("failed to parse temperature %s: %w", data, ErrTemperatureUnitMissing)
+		}
+		return err
+	}
+	return t.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [Temperature] object.
+func (t *Temperature) UnmarshalText(data []byte) error {
+	temp, err := ParseTemperature(string(data))
+	if err != nil {
+		return err
+	}
+	*t = temp
+	return nil
+}
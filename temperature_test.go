@@ -0,0 +1,36 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestTemperature1(t *testing.T) {
+	for str, celsius := range map[string]float64{"72F": 22.222, "22.5C": 22.5, "300K": 26.85, "-40 °F": -40, "0 kelvin": -273.15} {
+		temp, err := types.ParseTemperature(str)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %v", str, err)
+			continue
+		}
+		if math.Abs(temp.Celsius()-celsius) > 0.001 {
+			t.Errorf("expected '%s' to be %gC but got %s", str, celsius, temp)
+		}
+		t.Logf("%s = %s = %s = %s", str, temp, temp.Format(types.Fahrenheit, 1), temp.Format(types.Kelvin, 2))
+	}
+	for _, str := range []string{"72", "10X", "-1K", "hot"} {
+		if _, err := types.ParseTemperature(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+
+	var sensor struct {
+		Threshold types.Temperature `json:"threshold"`
+	}
+	if err := json.Unmarshal([]byte(`{"threshold": 85}`), &sensor); !errors.Is(err, types.ErrTemperatureUnitMissing) {
+		t.Errorf("expected unit-less number to be rejected but got: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// TemplateString is a string which is parsed as a [text/template] template when it is created or unmarshaled, so that
+// syntax errors are reported when configuration is loaded rather than when the template is first used.
+//
+// Templates are executed with the "missingkey=error" option, so referencing a map key which does not exist is an
+// error rather than producing "<no value>".
+type TemplateString struct {
+	raw  string
+	tmpl *template.Template
+}
+
+// ParseTemplateString parses the given string into a [TemplateString] object.
+func ParseTemplateString(tmpl string) (TemplateString, error) {
+	t, err := template.New("").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return TemplateString{}, fmt.Errorf("failed to parse template '%s': %w", tmpl, err)
+	}
+	return TemplateString{raw: tmpl, tmpl: t}, nil
+}
+
+// MarshalJSON marshals the [TemplateString] object to JSON as its original template text.
+func (t TemplateString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.raw)
+}
+
+// MarshalText marshals the [TemplateString] object to its original template text.
+func (t TemplateString) MarshalText() ([]byte, error) {
+	return []byte(t.raw), nil
+}
+
+// Render executes the template with the given data and returns the result.
+//
+// Rendering a zero value returns an empty string.
+func (t TemplateString) Render(data any) (string, error) {
+	if t.tmpl == nil {
+		return "", nil
+	}
+	var sb strings.Builder
+	if err := t.tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to render template '%s': %w", t.raw, err)
+	}
+	return sb.String(), nil
+}
+
+// String returns the original template text.
+func (t TemplateString) String() string {
+	return t.raw
+}
+
+// UnmarshalJSON parses the JSON string as a [TemplateString] object.
+func (t *TemplateString) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text as a [TemplateString] object.
+func (t *TemplateString) UnmarshalText(data []byte) error {
+	tmpl, err := ParseTemplateString(string(data))
+	if err != nil {
+		return err
+	}
+	*t = tmpl
+	return nil
+}
@@ -0,0 +1,29 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestTemplateString1(t *testing.T) {
+	var config struct {
+		Subject types.TemplateString `json:"subject"`
+	}
+	if err := json.Unmarshal([]byte(`{"subject": "[{{.Severity}}] {{.Host}} is down"}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal template: %v", err)
+	}
+	subject, err := config.Subject.Render(map[string]string{"Severity": "critical", "Host": "web01"})
+	if err != nil || subject != "[critical] web01 is down" {
+		t.Errorf("unexpected rendered template: %q (%v)", subject, err)
+	}
+	if _, err := config.Subject.Render(map[string]string{"Severity": "critical"}); err == nil {
+		t.Errorf("expected missing key to fail")
+	}
+	if err := json.Unmarshal([]byte(`{"subject": "{{.Host"}`), &config); err == nil {
+		t.Errorf("expected template syntax error to fail at load time")
+	}
+	out, _ := json.Marshal(config)
+	t.Logf("config: %s", out)
+}
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"slices"
+	"strings"
+)
+
+// Trie is a generic prefix tree which maps string keys to values.
+//
+// Keys are split into bytes, so it can be used for longest-prefix matching on routes, file paths or any other
+// strings. The zero value is an empty trie ready to use. Trie objects are not safe for concurrent use.
+type Trie[V any] struct {
+	root trieNode[V]
+	size int
+}
+
+// trieNode is a single node in a [Trie] object.
+type trieNode[V any] struct {
+	children map[byte]*trieNode[V]
+	hasValue bool
+	value    V
+}
+
+// NewTrie creates a new, empty Trie object.
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// Delete removes the given key from the trie and returns whether or not it was present.
+func (t *Trie[V]) Delete(key string) bool {
+	path := make([]*trieNode[V], 0, len(key)+1)
+	n := &t.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		if n = n.children[key[i]]; n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.hasValue {
+		return false
+	}
+
+	var zero V
+	n.hasValue, n.value = false, zero
+	t.size--
+
+	// prune nodes which no longer lead to any values
+	for i := len(key); i > 0; i-- {
+		if path[i].hasValue || len(path[i].children) > 0 {
+			break
+		}
+		delete(path[i-1].children, key[i-1])
+	}
+	return true
+}
+
+// Get returns the value stored for the given key and whether or not it was found.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Insert stores the value for the given key, replacing any existing value.
+func (t *Trie[V]) Insert(key string, value V) {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		if n.children == nil {
+			n.children = map[byte]*trieNode[V]{}
+		}
+		child, exists := n.children[key[i]]
+		if !exists {
+			child = &trieNode[V]{}
+			n.children[key[i]] = child
+		}
+		n = child
+	}
+	if !n.hasValue {
+		t.size++
+	}
+	n.hasValue, n.value = true, value
+}
+
+// Len returns the number of keys in the trie.
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+// LongestPrefix returns the longest key in the trie which is a prefix of the given string along with its value.
+//
+// If no key is a prefix of the string, an empty key, the zero value and false are returned.
+func (t *Trie[V]) LongestPrefix(str string) (string, V, bool) {
+	var (
+		match string
+		value V
+		found bool
+	)
+	n := &t.root
+	for i := 0; ; i++ {
+		if n.hasValue {
+			match, value, found = str[:i], n.value, true
+		}
+		if i == len(str) {
+			break
+		}
+		if n = n.children[str[i]]; n == nil {
+			break
+		}
+	}
+	return match, value, found
+}
+
+// WalkPrefix calls fn for each key in the trie which starts with the given prefix, in lexical order.
+//
+// Walking stops early if fn returns false.
+func (t *Trie[V]) WalkPrefix(prefix string, fn func(key string, value V) bool) {
+	n := t.find(prefix)
+	if n == nil {
+		return
+	}
+	var key strings.Builder
+	key.WriteString(prefix)
+	n.walk(&key, fn)
+}
+
+// find returns the node for the given key or nil if there is no such node.
+func (t *Trie[V]) find(key string) *trieNode[V] {
+	n := &t.root
+	for i := 0; i < len(key) && n != nil; i++ {
+		n = n.children[key[i]]
+	}
+	return n
+}
+
+// walk calls fn for the node and each of its descendants which hold a value, returning false if walking was stopped.
+func (n *trieNode[V]) walk(key *strings.Builder, fn func(string, V) bool) bool {
+	if n.hasValue && !fn(key.String(), n.value) {
+		return false
+	}
+	labels := make([]byte, 0, len(n.children))
+	for b := range n.children {
+		labels = append(labels, b)
+	}
+	slices.Sort(labels)
+
+	prefix := key.String()
+	for _, b := range labels {
+		key.Reset()
+		key.WriteString(prefix)
+		key.WriteByte(b)
+		if !n.children[b].walk(key, fn) {
+			return false
+		}
+	}
+	return true
+}
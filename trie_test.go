@@ -0,0 +1,39 @@
+package types_test
+
+import (
+	"slices"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestTrie1(t *testing.T) {
+	routes := types.NewTrie[string]()
+	routes.Insert("/", "root")
+	routes.Insert("/api/", "api")
+	routes.Insert("/api/v1/", "v1")
+	routes.Insert("/api/v2/", "v2")
+	routes.Insert("/static/", "static")
+
+	for path, expected := range map[string]string{"/api/v1/users": "v1", "/api/v3": "api", "/index.html": "root"} {
+		if prefix, route, ok := routes.LongestPrefix(path); !ok || route != expected {
+			t.Errorf("expected %s to route to %s but got %s (%s)", path, expected, route, prefix)
+		}
+	}
+
+	var keys []string
+	routes.WalkPrefix("/api/", func(key string, _ string) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if !slices.Equal(keys, []string{"/api/", "/api/v1/", "/api/v2/"}) {
+		t.Errorf("unexpected keys under prefix: %v", keys)
+	}
+
+	if !routes.Delete("/api/v1/") || routes.Delete("/api/v1/") || routes.Len() != 4 {
+		t.Errorf("unexpected delete results")
+	}
+	if _, route, _ := routes.LongestPrefix("/api/v1/users"); route != "api" {
+		t.Errorf("expected deleted route to fall back to api but got %s", route)
+	}
+}
@@ -1,45 +1,311 @@
 package types
 
 import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"math/rand"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// uuidV7EntropySize is the number of random bytes used to generate each v7 UUID.
+const uuidV7EntropySize = 8
+
+// NilUUID is the UUID with all bits set to zero.
+var NilUUID UUID
+
+var (
+	// uuidV7Last is the timestamp of the last v7 UUID generated by this package. See [reserveUUIDv7Times].
+	uuidV7Last int64
+
+	// uuidV7Mu guards access to uuidV7Last.
+	uuidV7Mu sync.Mutex
+)
+
+// Well-known namespaces for use with [NewUUIDv5] as defined in RFC 9562.
+var (
+	NamespaceDNS  = UUID(uuid.NameSpaceDNS)
+	NamespaceURL  = UUID(uuid.NameSpaceURL)
+	NamespaceOID  = UUID(uuid.NameSpaceOID)
+	NamespaceX500 = UUID(uuid.NameSpaceX500)
+)
+
+// UUID represents a 128-bit universally unique identifier.
+//
+// It is formatted as an uppercase string in the canonical 8-4-4-4-12 format and can be compared directly with == or
+// ordered with [UUID.Compare].
+type UUID [16]byte
+
 // NewUUID generates a new UUID.
 //
-// This function first attempts to generate a v7 UUID.  If that fails, then a v8 UUID is generated instead.
+// This function first attempts to generate a v7 UUID.  If that fails, then a v8 UUID is generated instead.  Both use
+// the system's secure random number generator, and this function panics if it cannot be read.  Use [NewUUIDv7] to
+// receive an error instead, or [NewUUIDv4] or [NewUUIDv5] to generate a specific version of UUID.
 func NewUUID() string {
-	id, err := uuid.NewV7()
+	return newUUID().String()
+}
+
+// IsValidUUID returns whether or not the given string can be parsed by [ParseUUID].
+func IsValidUUID(id string) bool {
+	_, err := ParseUUID(id)
+	return err == nil
+}
+
+// MustParseUUID is like [ParseUUID] but panics if the string cannot be parsed.
+func MustParseUUID(id string) UUID {
+	u, err := ParseUUID(id)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// NewUUIDBatch generates n new time-ordered (v7) UUIDs.
+//
+// The random bits for the entire batch are read from the system's secure random number generator at once and the
+// clock is only read once, which is considerably faster than generating each UUID individually when many are needed.
+// The UUIDs are returned in the order in which they sort.
+func NewUUIDBatch(n int) ([]UUID, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	entropy := make([]byte, n*uuidV7EntropySize)
+	if _, err := crand.Read(entropy); err != nil {
+		return nil, fmt.Errorf("failed to generate UUID batch: %w", err)
+	}
+	ts := reserveUUIDv7Times(n)
+	ids := make([]UUID, n)
+	for i := range ids {
+		ids[i] = newUUIDv7(ts+int64(i), entropy[i*uuidV7EntropySize:])
+	}
+	return ids, nil
+}
+
+// NewUUIDv4 generates a new random (v4) UUID.
+func NewUUIDv4() (UUID, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return NilUUID, fmt.Errorf("failed to generate v4 UUID: %w", err)
+	}
+	return UUID(id), nil
+}
+
+// NewUUIDv5 generates a name-based (v5) UUID from the SHA-1 hash of the namespace and name.
+//
+// The same namespace and name always produce the same UUID.
+func NewUUIDv5(namespace UUID, name string) UUID {
+	return UUID(uuid.NewSHA1(uuid.UUID(namespace), []byte(name)))
+}
+
+// NewUUIDv7 generates a new time-ordered (v7) UUID.
+//
+// UUIDs generated by the same process sort in the order in which they were generated. Use [UUID.Timestamp] to
+// retrieve the time at which the UUID was generated.
+func NewUUIDv7() (UUID, error) {
+	var entropy [uuidV7EntropySize]byte
+	if _, err := crand.Read(entropy[:]); err != nil {
+		return NilUUID, fmt.Errorf("failed to generate v7 UUID: %w", err)
+	}
+	return newUUIDv7(reserveUUIDv7Times(1), entropy[:]), nil
+}
+
+// ParseUUID parses the given string into a [UUID] object.
+//
+// The string may be in any of the following forms, with hexadecimal digits in upper or lower case:
+//
+//   - canonical: XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX
+//   - braced: {XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX}
+//   - URN: urn:uuid:XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX
+//   - hex: XXXXXXXXXXXXXXXXXXXXXXXXXXXXXXXX
+//
+// Any other form, including one with surrounding whitespace, is rejected. Use [UUID.String] to obtain the normalized
+// canonical form.
+func ParseUUID(id string) (UUID, error) {
+	var u UUID
+	var digits string
+	switch len(id) {
+	case 36:
+		digits = id
+	case 38:
+		if id[0] != '{' || id[37] != '}' {
+			return u, fmt.Errorf("failed to parse UUID '%s': mismatched braces", id)
+		}
+		digits = id[1:37]
+	case 45:
+		if !strings.EqualFold(id[:9], "urn:uuid:") {
+			return u, fmt.Errorf("failed to parse UUID '%s': expected 'urn:uuid:' prefix", id)
+		}
+		digits = id[9:]
+	case 32:
+		if _, err := hex.Decode(u[:], []byte(id)); err != nil {
+			return NilUUID, fmt.Errorf("failed to parse UUID '%s': %w", id, err)
+		}
+		return u, nil
+	default:
+		return u, fmt.Errorf("failed to parse UUID '%s': invalid length %d", id, len(id))
+	}
+
+	if digits[8] != '-' || digits[13] != '-' || digits[18] != '-' || digits[23] != '-' {
+		return u, fmt.Errorf("failed to parse UUID '%s': expected format 'XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX'", id)
+	}
+	digits = digits[0:8] + digits[9:13] + digits[14:18] + digits[19:23] + digits[24:]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return NilUUID, fmt.Errorf("failed to parse UUID '%s': %w", id, err)
+	}
+	return u, nil
+}
+
+// Compare returns -1 if the UUID sorts before other, 1 if it sorts after other and 0 if they are equal.
+//
+// UUIDs are compared byte by byte, so v7 UUIDs sort in the order in which they were generated.
+func (u UUID) Compare(other UUID) int {
+	return bytes.Compare(u[:], other[:])
+}
+
+// IsNil returns whether or not every bit of the UUID is zero.
+func (u UUID) IsNil() bool {
+	return u == NilUUID
+}
+
+// MarshalBinary marshals the [UUID] object to its 16 raw bytes.
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return u[:], nil
+}
+
+// MarshalJSON marshals the [UUID] object to JSON.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.String())
+}
+
+// MarshalText marshals the [UUID] object to text.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// String returns the [UUID] object as an uppercase string in the canonical 8-4-4-4-12 format.
+func (u UUID) String() string {
+	return strings.ToUpper(fmt.Sprintf("%s-%s-%s-%s-%s",
+		hex.EncodeToString(u[0:4]),
+		hex.EncodeToString(u[4:6]),
+		hex.EncodeToString(u[6:8]),
+		hex.EncodeToString(u[8:10]),
+		hex.EncodeToString(u[10:])))
+}
+
+// Timestamp returns the time at which a v7 UUID was generated, with millisecond precision.
+//
+// If the UUID is not a v7 UUID, a zero time and false are returned.
+func (u UUID) Timestamp() (time.Time, bool) {
+	if u.Version() != 7 {
+		return time.Time{}, false
+	}
+	var ms int64
+	for _, b := range u[:6] {
+		ms = ms<<8 | int64(b)
+	}
+	return time.UnixMilli(ms), true
+}
+
+// UnmarshalBinary parses the 16 raw bytes into a [UUID] object.
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	if len(data) != len(u) {
+		return fmt.Errorf("failed to parse UUID: expected %d bytes but got %d", len(u), len(data))
+	}
+	copy(u[:], data)
+	return nil
+}
+
+// UnmarshalJSON parses the JSON string into a [UUID] object.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return u.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [UUID] object.
+func (u *UUID) UnmarshalText(data []byte) error {
+	id, err := ParseUUID(string(data))
+	if err != nil {
+		return err
+	}
+	*u = id
+	return nil
+}
+
+// Version returns the version number stored in bits 48-51 of the UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// newUUID generates a new v7 UUID, falling back to a v8 UUID if that fails.
+func newUUID() UUID {
+	id, err := NewUUIDv7()
 	if err != nil {
 		return generateUUIDv8()
 	}
-	return strings.ToUpper(id.String())
+	return id
+}
+
+// newUUIDv7 returns a v7 UUID for the given timestamp returned by [reserveUUIDv7Times] using the first 8 bytes of
+// entropy as its random bits.
+func newUUIDv7(ts int64, entropy []byte) UUID {
+	// the upper 48 bits hold the Unix timestamp in milliseconds and the next 12 bits hold the sub-millisecond fraction
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], uint64(ts>>12)<<16|uint64(ts&0xfff))
+	copy(u[8:], entropy[:uuidV7EntropySize])
+	return u.withVersion(7)
+}
+
+// reserveUUIDv7Times reserves n consecutive timestamps for v7 UUIDs and returns the first of them.
+//
+// Timestamps are measured in 1/4096ths of a millisecond since the Unix epoch, so the lower 12 bits hold the
+// sub-millisecond precision which RFC 9562 allows to be stored in place of random bits. Reserved timestamps never
+// repeat or go backwards, so v7 UUIDs generated by this package sort in the order in which they were generated even
+// when the clock is adjusted or more than 4096 are generated per millisecond.
+func reserveUUIDv7Times(n int) int64 {
+	now := time.Now().UnixNano()
+	ts := now/int64(time.Millisecond)<<12 | now%int64(time.Millisecond)<<12/int64(time.Millisecond)
+
+	uuidV7Mu.Lock()
+	defer uuidV7Mu.Unlock()
+	if ts <= uuidV7Last {
+		ts = uuidV7Last + 1
+	}
+	uuidV7Last = ts + int64(n) - 1
+	return ts
 }
 
 // generateUUIDv8 generates a v8 UUID.
-func generateUUIDv8() string {
+//
+// The random bits are read from the system's secure random number generator. This is only used when generating a v7
+// UUID fails, which only happens if reading from that generator fails, so the read is attempted twice more. If it
+// still fails, this function panics rather than producing a predictable UUID.
+func generateUUIDv8() UUID {
 	// generate 16 random bytes
-	vals := make([]byte, 16)
-	for i := 0; i < 16; i++ {
-		vals[i] = byte(rand.Intn(255))
+	var vals UUID
+	var err error
+	for try := 0; try < 2; try++ {
+		if _, err = crand.Read(vals[:]); err == nil {
+			return vals.withVersion(8)
+		}
 	}
+	panic(fmt.Errorf("failed to generate UUID: %w", err))
+}
 
-	// replace bits 48-51 with the version (8)
-	// we do this by shifting left 4, discarding the
-	vals[6] = (((vals[6] << 4) & 255) >> 4) | 128
+// withVersion returns a copy of the UUID with the given version and the RFC 9562 variant set.
+func (u UUID) withVersion(version byte) UUID {
+	// replace bits 48-51 with the version
+	u[6] = u[6]&0x0f | version<<4
 
 	// replace bits 64 and 65 with the variant (2)
-	vals[8] = (((vals[8] << 2) & 255) >> 2) | 128
-
-	// turn the whole thing into a string
-	return strings.ToUpper(fmt.Sprintf("%s-%s-%s-%s-%s",
-		hex.EncodeToString(vals[0:4]),
-		hex.EncodeToString(vals[4:6]),
-		hex.EncodeToString(vals[6:8]),
-		hex.EncodeToString(vals[8:10]),
-		hex.EncodeToString(vals[10:])))
+	u[8] = u[8]&0x3f | 0x80
+	return u
 }
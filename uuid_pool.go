@@ -0,0 +1,54 @@
+package types
+
+import (
+	crand "crypto/rand"
+	"sync"
+)
+
+// defaultUUIDPoolSize is the number of UUIDs worth of random bits buffered by a [UUIDPool] object if no size is given.
+const defaultUUIDPoolSize = 256
+
+// UUIDPool generates time-ordered (v7) UUIDs using random bits which are read from the system's secure random number
+// generator in bulk, so that most UUIDs can be generated without waiting for it.
+//
+// It is intended for high-throughput pipelines which need a new UUID for every event. Only the random bits are
+// buffered: the timestamp of each UUID is taken when it is retrieved, so it is always current and UUIDs from the pool
+// sort in the order in which they were retrieved along with any others generated by [NewUUIDv7] or [NewUUIDBatch].
+// UUIDPool objects are safe for concurrent use.
+type UUIDPool struct {
+	entropy []byte
+	mu      sync.Mutex
+	next    int
+}
+
+// NewUUIDPool creates a new UUIDPool object which buffers enough random bits for size UUIDs at a time. If size is 0
+// or less, random bits are buffered for 256 UUIDs.
+func NewUUIDPool(size int) *UUIDPool {
+	if size <= 0 {
+		size = defaultUUIDPoolSize
+	}
+	entropy := make([]byte, size*uuidV7EntropySize)
+	return &UUIDPool{
+		entropy: entropy,
+		next:    len(entropy),
+	}
+}
+
+// Get generates a new UUID, refilling the buffer of random bits first if it is empty.
+//
+// If the buffer cannot be refilled, the UUID is generated in the same way as [NewUUID], so this function panics if
+// the system's secure random number generator cannot be read.
+func (p *UUIDPool) Get() UUID {
+	var entropy [uuidV7EntropySize]byte
+	p.mu.Lock()
+	if p.next == len(p.entropy) {
+		if _, err := crand.Read(p.entropy); err != nil {
+			p.mu.Unlock()
+			return newUUID()
+		}
+		p.next = 0
+	}
+	p.next += copy(entropy[:], p.entropy[p.next:])
+	p.mu.Unlock()
+	return newUUIDv7(reserveUUIDv7Times(1), entropy[:])
+}
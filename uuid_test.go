@@ -0,0 +1,242 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestUUID1(t *testing.T) {
+	id, err := types.ParseUUID(types.NewUUID())
+	if err != nil {
+		t.Fatalf("failed to parse generated UUID: %v", err)
+	}
+	if id.IsNil() || id.Version() != 7 {
+		t.Errorf("unexpected UUID version: %d", id.Version())
+	}
+
+	var config struct {
+		ID types.UUID `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(`{"id": "0190a5e8-7c3b-7def-8a12-3456789abcde"}`), &config); err != nil {
+		t.Fatalf("failed to unmarshal UUID: %v", err)
+	}
+	if config.ID.String() != "0190A5E8-7C3B-7DEF-8A12-3456789ABCDE" {
+		t.Errorf("unexpected UUID: %s", config.ID)
+	}
+	if config.ID.Compare(id) >= 0 || id.Compare(config.ID) <= 0 || id.Compare(id) != 0 {
+		t.Errorf("unexpected UUID ordering")
+	}
+	t.Logf("UUID: %s", config.ID)
+
+	data, _ := config.ID.MarshalBinary()
+	var decoded types.UUID
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded != config.ID {
+		t.Errorf("failed to round-trip binary UUID: %v", err)
+	}
+
+	for _, bad := range []string{"", "0190a5e8-7c3b-7def-8a12-3456789abcd", "0190a5e8x7c3b-7def-8a12-3456789abcde",
+		"0190a5e8-7c3b-7def-8a12-3456789abcdg"} {
+		if _, err := types.ParseUUID(bad); err == nil {
+			t.Errorf("expected '%s' to fail", bad)
+		}
+	}
+}
+
+func TestUUID2(t *testing.T) {
+	const canonical = "6BA7B810-9DAD-11D1-80B4-00C04FD430C8"
+	for _, str := range []string{
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"URN:UUID:6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+	} {
+		id, err := types.ParseUUID(str)
+		if err != nil {
+			t.Errorf("failed to parse '%s': %v", str, err)
+			continue
+		}
+		if id.String() != canonical {
+			t.Errorf("expected '%s' to normalize to '%s' but got '%s'", str, canonical, id)
+		}
+		t.Logf("%s => %s", str, id)
+	}
+
+	for _, bad := range []string{
+		" 6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"(6ba7b810-9dad-11d1-80b4-00c04fd430c8)",
+		"uuid:urn:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b8109dad11d180b400c04fd430cz",
+		"6ba7b810-9dad-11d1-80b400c04fd430c8",
+	} {
+		if types.IsValidUUID(bad) {
+			t.Errorf("expected '%s' to be invalid", bad)
+		}
+	}
+}
+
+func TestUUID3(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	v7, err := types.NewUUIDv7()
+	if err != nil {
+		t.Fatalf("failed to generate v7 UUID: %v", err)
+	}
+	ts, ok := v7.Timestamp()
+	if !ok || v7.Version() != 7 || ts.Before(before) || ts.After(time.Now()) {
+		t.Errorf("unexpected v7 UUID timestamp: %s (%v)", ts, ok)
+	}
+	t.Logf("v7 UUID: %s (%s)", v7, ts)
+
+	v4, err := types.NewUUIDv4()
+	if err != nil {
+		t.Fatalf("failed to generate v4 UUID: %v", err)
+	}
+	if _, ok := v4.Timestamp(); ok || v4.Version() != 4 {
+		t.Errorf("unexpected v4 UUID: %s", v4)
+	}
+
+	// known value from RFC 9562 appendix A.4
+	v5 := types.NewUUIDv5(types.NamespaceDNS, "www.example.com")
+	if v5.String() != "2ED6657D-E927-568B-95E1-2665A8AEA6A2" || v5.Version() != 5 {
+		t.Errorf("unexpected v5 UUID: %s", v5)
+	}
+	if types.NewUUIDv5(types.NamespaceDNS, "www.example.com") != v5 {
+		t.Errorf("expected v5 UUIDs to be deterministic")
+	}
+}
+
+func TestUUID4(t *testing.T) {
+	ids, err := types.NewUUIDBatch(1000)
+	if err != nil {
+		t.Fatalf("failed to generate UUID batch: %v", err)
+	}
+	if len(ids) != 1000 {
+		t.Fatalf("expected 1000 UUIDs but got %d", len(ids))
+	}
+	seen := map[types.UUID]bool{}
+	for i, id := range ids {
+		if id.Version() != 7 || seen[id] {
+			t.Fatalf("unexpected or duplicate UUID: %s", id)
+		}
+		if i > 0 && ids[i-1].Compare(id) >= 0 {
+			t.Fatalf("expected UUIDs to be ordered: %s >= %s", ids[i-1], id)
+		}
+		seen[id] = true
+	}
+	t.Logf("first UUID: %s, last UUID: %s", ids[0], ids[len(ids)-1])
+
+	// UUIDs from the pool, NewUUIDv7 and NewUUIDBatch all sort in the order in which they were generated
+	pool := types.NewUUIDPool(16)
+	last := ids[len(ids)-1]
+	for i := 0; i < 100; i++ {
+		id := pool.Get()
+		if i%10 == 0 {
+			id, _ = types.NewUUIDv7()
+		}
+		if id.Version() != 7 || seen[id] {
+			t.Fatalf("unexpected or duplicate UUID from pool: %s", id)
+		}
+		if last.Compare(id) >= 0 {
+			t.Fatalf("expected UUIDs to be ordered: %s >= %s", last, id)
+		}
+		seen[id] = true
+		last = id
+	}
+
+	// the timestamp is taken when the UUID is retrieved rather than when the pool was filled
+	time.Sleep(20 * time.Millisecond)
+	before := time.Now().Truncate(time.Millisecond)
+	if ts, _ := pool.Get().Timestamp(); ts.Before(before) {
+		t.Errorf("expected a current timestamp but got %s (before %s)", ts, before)
+	}
+}
+
+func BenchmarkNewUUIDv7(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if _, err := types.NewUUIDv7(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewUUIDBatch(b *testing.B) {
+	for i := 0; i < b.N; i += 256 {
+		if _, err := types.NewUUIDBatch(256); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNewUUIDv7Parallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := types.NewUUIDv7(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkUUIDPool(b *testing.B) {
+	pool := types.NewUUIDPool(0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.Get()
+	}
+}
+
+func BenchmarkUUIDPoolParallel(b *testing.B) {
+	pool := types.NewUUIDPool(0)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			pool.Get()
+		}
+	})
+}
+
+func TestUUID5(t *testing.T) {
+	generate := func() []types.UUID {
+		clock := &manualClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+		gen := types.NewDeterministicUUIDGenerator(42, clock)
+		var ids []types.UUID
+		for i := 0; i < 3; i++ {
+			v4, err := gen.NewV4()
+			if err != nil {
+				t.Fatalf("failed to generate v4 UUID: %v", err)
+			}
+			v7, err := gen.NewV7()
+			if err != nil {
+				t.Fatalf("failed to generate v7 UUID: %v", err)
+			}
+			ids = append(ids, v4, v7)
+		}
+		return ids
+	}
+
+	first, second := generate(), generate()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Errorf("expected UUID %d to be reproducible: %s != %s", i, first[i], second[i])
+		}
+		t.Logf("UUID %d: %s", i, first[i])
+	}
+	if first[0].Version() != 4 || first[1].Version() != 7 {
+		t.Errorf("unexpected UUID versions: %d, %d", first[0].Version(), first[1].Version())
+	}
+	if first[1].Compare(first[3]) >= 0 || first[3].Compare(first[5]) >= 0 {
+		t.Errorf("expected v7 UUIDs within the same millisecond to be ordered")
+	}
+	if ts, _ := first[5].Timestamp(); !ts.Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected v7 UUID timestamp: %s", ts)
+	}
+
+	id, err := types.NewUUIDGenerator(types.UUIDGeneratorOptions{}).NewV7()
+	if ts, _ := id.Timestamp(); err != nil || time.Since(ts) > time.Minute {
+		t.Errorf("unexpected UUID from default generator: %s (%v)", id, err)
+	}
+}
@@ -0,0 +1,344 @@
+package types
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version represents a semantic version as defined by the Semantic Versioning 2.0.0 specification.
+type Version struct {
+	// Major is the major version number.
+	Major uint64
+
+	// Minor is the minor version number.
+	Minor uint64
+
+	// Patch is the patch version number.
+	Patch uint64
+
+	// Prerelease holds the dot-separated pre-release identifiers, such as "rc.1", or an empty string.
+	Prerelease string
+
+	// Build holds the dot-separated build metadata, such as "20240601.sha.abc123", or an empty string.
+	Build string
+}
+
+// ParseVersion parses the given string into a [Version] object.
+//
+// The string must be a full semantic version such as "1.2.3", "1.2.3-rc.1" or "1.2.3+build.5". A leading "v" is
+// permitted and ignored.
+func ParseVersion(version string) (Version, error) {
+	str := strings.TrimPrefix(strings.TrimSpace(version), "v")
+
+	var (
+		v                       Version
+		hasBuild, hasPrerelease bool
+	)
+	str, v.Build, hasBuild = strings.Cut(str, "+")
+	str, v.Prerelease, hasPrerelease = strings.Cut(str, "-")
+
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return Version{}, fmt.Errorf("failed to parse version '%s': expected MAJOR.MINOR.PATCH", version)
+	}
+	nums := make([]uint64, 3)
+	for i, part := range parts {
+		if !isNumericIdentifier(part) {
+			return Version{}, fmt.Errorf("failed to parse version '%s': invalid version number '%s'", version, part)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("failed to parse version '%s': %w", version, err)
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+
+	if hasPrerelease && v.Prerelease == "" || !validVersionIdentifiers(v.Prerelease, true) {
+		return Version{}, fmt.Errorf("failed to parse version '%s': invalid pre-release '%s'", version, v.Prerelease)
+	}
+	if hasBuild && v.Build == "" || !validVersionIdentifiers(v.Build, false) {
+		return Version{}, fmt.Errorf("failed to parse version '%s': invalid build metadata '%s'", version, v.Build)
+	}
+	return v, nil
+}
+
+// Compare returns -1, 0 or +1 depending on whether the version is lower than, equal to or higher than the given
+// version.
+//
+// Build metadata is ignored and a pre-release version is lower than the associated normal version, as required by
+// the specification.
+func (v Version) Compare(v2 Version) int {
+	if c := cmp.Compare(v.Major, v2.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, v2.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, v2.Patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.Prerelease == v2.Prerelease:
+		return 0
+	case v.Prerelease == "":
+		return 1
+	case v2.Prerelease == "":
+		return -1
+	}
+	ids, ids2 := strings.Split(v.Prerelease, "."), strings.Split(v2.Prerelease, ".")
+	for i := 0; i < min(len(ids), len(ids2)); i++ {
+		if c := comparePrereleaseIdentifier(ids[i], ids2[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(ids), len(ids2))
+}
+
+// IsPrerelease returns whether or not the version is a pre-release version.
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// MarshalJSON marshals the [Version] object to JSON.
+func (v Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+// MarshalText marshals the [Version] object to plain text.
+func (v Version) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// String returns the [Version] object as a string, such as "1.2.3-rc.1".
+func (v Version) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		str += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		str += "+" + v.Build
+	}
+	return str
+}
+
+// UnmarshalJSON parses the JSON data into a [Version] object.
+func (v *Version) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return v.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [Version] object.
+func (v *Version) UnmarshalText(data []byte) error {
+	version, err := ParseVersion(string(data))
+	if err != nil {
+		return err
+	}
+	*v = version
+	return nil
+}
+
+// VersionConstraint is a set of conditions which a [Version] object can be checked against.
+//
+// It is parsed from expressions such as ">=1.2.0 <2.0.0", "^1.4.0" or "~1.2.3 || >=2.1.0". Conditions separated by
+// spaces or commas must all be satisfied, while groups separated by "||" are alternatives. The supported operators
+// are "=", "!=", ">", ">=", "<", "<=", "~" (same major and minor version) and "^" (no change to the left-most
+// non-zero version number). A version with no operator must match exactly.
+type VersionConstraint struct {
+	groups [][]versionCondition
+	raw    string
+}
+
+// versionCondition is a single comparison within a [VersionConstraint] object.
+type versionCondition struct {
+	op      string
+	version Version
+}
+
+// versionOperators lists the supported constraint operators with longer operators before their prefixes.
+var versionOperators = []string{">=", "<=", "!=", ">", "<", "=", "~", "^"}
+
+// ParseVersionConstraint parses the given expression into a [VersionConstraint] object.
+func ParseVersionConstraint(constraint string) (VersionConstraint, error) {
+	c := VersionConstraint{raw: strings.TrimSpace(constraint)}
+	for _, group := range strings.Split(c.raw, "||") {
+		var conds []versionCondition
+		fields := strings.FieldsFunc(group, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			op := "="
+			for _, o := range versionOperators {
+				if strings.HasPrefix(field, o) {
+					op, field = o, field[len(o):]
+					break
+				}
+			}
+			// allow a space between the operator and the version, such as ">= 1.2.0"
+			if field == "" && i+1 < len(fields) {
+				i++
+				field = fields[i]
+			}
+			v, err := ParseVersion(field)
+			if err != nil {
+				return VersionConstraint{}, fmt.Errorf("failed to parse version constraint '%s': %w", constraint, err)
+			}
+			conds = append(conds, versionCondition{op: op, version: v})
+		}
+		if len(conds) == 0 {
+			return VersionConstraint{}, fmt.Errorf("failed to parse version constraint '%s': empty condition",
+				constraint)
+		}
+		c.groups = append(c.groups, conds)
+	}
+	return c, nil
+}
+
+// Check returns whether or not the given version satisfies the constraint.
+func (c VersionConstraint) Check(v Version) bool {
+	for _, group := range c.groups {
+		matched := true
+		for _, cond := range group {
+			if !cond.check(v) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON marshals the [VersionConstraint] object to JSON.
+func (c VersionConstraint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+// MarshalText marshals the [VersionConstraint] object to plain text.
+func (c VersionConstraint) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// String returns the [VersionConstraint] object as the expression it was parsed from.
+func (c VersionConstraint) String() string {
+	return c.raw
+}
+
+// UnmarshalJSON parses the JSON data into a [VersionConstraint] object.
+func (c *VersionConstraint) UnmarshalJSON(data []byte) error {
+	var sval string
+	if err := json.Unmarshal(data, &sval); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(sval))
+}
+
+// UnmarshalText parses the text into a [VersionConstraint] object.
+func (c *VersionConstraint) UnmarshalText(data []byte) error {
+	constraint, err := ParseVersionConstraint(string(data))
+	if err != nil {
+		return err
+	}
+	*c = constraint
+	return nil
+}
+
+// check returns whether or not the given version satisfies the condition.
+func (c versionCondition) check(v Version) bool {
+	res := v.Compare(c.version)
+	switch c.op {
+	case "!=":
+		return res != 0
+	case ">":
+		return res > 0
+	case ">=":
+		return res >= 0
+	case "<":
+		return res < 0
+	case "<=":
+		return res <= 0
+	case "~":
+		upper := Version{Major: c.version.Major, Minor: c.version.Minor + 1}
+		return res >= 0 && v.Compare(upper) < 0
+	case "^":
+		var upper Version
+		switch {
+		case c.version.Major > 0:
+			upper = Version{Major: c.version.Major + 1}
+		case c.version.Minor > 0:
+			upper = Version{Minor: c.version.Minor + 1}
+		default:
+			upper = Version{Patch: c.version.Patch + 1}
+		}
+		return res >= 0 && v.Compare(upper) < 0
+	default:
+		return res == 0
+	}
+}
+
+// comparePrereleaseIdentifier compares a single pre-release identifier, ordering numeric identifiers numerically and
+// before alphanumeric identifiers.
+func comparePrereleaseIdentifier(a, b string) int {
+	aNum, bNum := isNumericIdentifier(a), isNumericIdentifier(b)
+	switch {
+	case aNum && bNum:
+		if c := cmp.Compare(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// isNumericIdentifier returns whether or not the given string is a non-empty number without leading zeros.
+func isNumericIdentifier(str string) bool {
+	if str == "" || (len(str) > 1 && str[0] == '0') {
+		return false
+	}
+	for _, r := range str {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validVersionIdentifiers returns whether or not the given dot-separated pre-release or build identifiers are valid.
+//
+// An empty string is valid and means there are no identifiers.
+func validVersionIdentifiers(str string, prerelease bool) bool {
+	if str == "" {
+		return true
+	}
+	for _, id := range strings.Split(str, ".") {
+		if id == "" {
+			return false
+		}
+		numeric := true
+		for _, r := range id {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if prerelease && numeric && !isNumericIdentifier(id) {
+			return false
+		}
+	}
+	return true
+}
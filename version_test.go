@@ -0,0 +1,58 @@
+package types_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"go.innotegrity.dev/types"
+)
+
+func TestVersion1(t *testing.T) {
+	// ordering example taken from the Semantic Versioning 2.0.0 specification
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "v1.0.1+build.5", "1.10.0"}
+	versions := make([]types.Version, len(ordered))
+	for i, str := range ordered {
+		v, err := types.ParseVersion(str)
+		if err != nil {
+			t.Fatalf("failed to parse version '%s': %v", str, err)
+		}
+		versions[i] = v
+	}
+	if !slices.IsSortedFunc(versions, types.Version.Compare) {
+		t.Errorf("expected versions to be sorted: %v", versions)
+	}
+	for _, str := range []string{"1.2", "1.02.3", "1.2.3-", "1.2.3-01", "1.2.3+", "1.2.3-rc..1"} {
+		if _, err := types.ParseVersion(str); err == nil {
+			t.Errorf("expected '%s' to fail to parse", str)
+		}
+	}
+}
+
+func TestVersion2(t *testing.T) {
+	var plugin struct {
+		Requires types.VersionConstraint `json:"requires"`
+	}
+	if err := json.Unmarshal([]byte(`{"requires": ">= 1.2.0, <2.0.0 || ^3.1.0"}`), &plugin); err != nil {
+		t.Fatalf("failed to unmarshal version constraint: %v", err)
+	}
+	for str, expected := range map[string]bool{
+		"1.1.9": false, "1.2.0": true, "1.9.9": true, "2.0.0": false, "3.0.0": false, "3.1.5": true, "4.0.0": false,
+	} {
+		v, _ := types.ParseVersion(str)
+		if plugin.Requires.Check(v) != expected {
+			t.Errorf("expected check of %s against '%s' to be %t", str, plugin.Requires, expected)
+		}
+	}
+
+	tilde, _ := types.ParseVersionConstraint("~0.4.2")
+	caret, _ := types.ParseVersionConstraint("^0.0.3")
+	if !tilde.Check(types.Version{Minor: 4, Patch: 9}) || tilde.Check(types.Version{Minor: 5}) ||
+		caret.Check(types.Version{Patch: 4}) {
+		t.Errorf("unexpected results for tilde and caret constraints")
+	}
+	if _, err := types.ParseVersionConstraint(">=1.2.0 ||"); err == nil {
+		t.Errorf("expected empty alternative to fail to parse")
+	}
+}
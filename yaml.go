@@ -0,0 +1,62 @@
+package types
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// yamlMarshal is the function used to encode YAML documents.
+	yamlMarshal func(any) ([]byte, error)
+
+	// yamlUnmarshal is the function used to decode YAML documents.
+	yamlUnmarshal func([]byte, any) error
+
+	// yamlMu guards access to yamlMarshal and yamlUnmarshal.
+	yamlMu sync.RWMutex
+)
+
+// RegisterYAML registers the functions used to encode and decode YAML documents.
+//
+// YAML is not supported by the standard library, so a YAML library must be registered before any of the YAML
+// functions (such as [Path.ReadYAML]) can be used:
+//
+//	types.RegisterYAML(yaml.Marshal, yaml.Unmarshal)
+func RegisterYAML(marshal func(any) ([]byte, error), unmarshal func([]byte, any) error) {
+	yamlMu.Lock()
+	defer yamlMu.Unlock()
+	yamlMarshal = marshal
+	yamlUnmarshal = unmarshal
+}
+
+// errYAMLNotRegistered is returned when a YAML function is used before [RegisterYAML] has been called.
+var errYAMLNotRegistered = errors.New("no YAML library has been registered")
+
+// marshalYAML encodes v as a YAML document using the registered YAML library.
+func marshalYAML(v any) ([]byte, error) {
+	yamlMu.RLock()
+	marshal := yamlMarshal
+	yamlMu.RUnlock()
+	if marshal == nil {
+		return nil, errYAMLNotRegistered
+	}
+	return marshal(v)
+}
+
+// unmarshalYAML decodes the YAML data into v using the registered YAML library.
+func unmarshalYAML(data []byte, v any) error {
+	yamlMu.RLock()
+	unmarshal := yamlUnmarshal
+	yamlMu.RUnlock()
+	if unmarshal == nil {
+		return errYAMLNotRegistered
+	}
+	return unmarshal(data, v)
+}
+
+// yamlRegistered returns whether or not a YAML library has been registered with [RegisterYAML].
+func yamlRegistered() bool {
+	yamlMu.RLock()
+	defer yamlMu.RUnlock()
+	return yamlMarshal != nil && yamlUnmarshal != nil
+}